@@ -0,0 +1,92 @@
+// Copyright (c) 2023 https://github.com/gio-eui
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR a PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+//
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"bufio"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/gio-eui/md3-palettes/scheme"
+)
+
+// check audits the WCAG 2 contrast of the role pairs of a palette, or their APCA lightness
+// contrast, and fails if any pair does not reach AA, or the APCA body text threshold.
+func check(args []string) error {
+	fs := flag.NewFlagSet("check", flag.ExitOnError)
+	var pf paletteFlags
+	pf.register(fs)
+	mode := fs.String("mode", "both", "schemes to check: light, dark or both")
+	apca := fs.Bool("apca", false, "audit the APCA lightness contrast instead of the WCAG 2 contrast ratio")
+	thresholds := scheme.DefaultAPCAThresholds
+	fs.Float64Var(&thresholds.BodyText, "body", thresholds.BodyText, "apca: minimum Lc of body text")
+	fs.Float64Var(&thresholds.LargeText, "large", thresholds.LargeText, "apca: minimum Lc of large text")
+	fs.Float64Var(&thresholds.NonText, "nontext", thresholds.NonText, "apca: minimum Lc of non-text elements")
+	_ = fs.Parse(args)
+
+	p, err := pf.palette()
+	if err != nil {
+		return err
+	}
+	modes, all, err := schemes(p, *mode)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	w := bufio.NewWriter(os.Stdout)
+	for _, m := range modes {
+		if *apca {
+			report := all[m].AuditAPCA(thresholds)
+			if failures := report.Failures(); len(failures) > 0 {
+				errs = append(errs, fmt.Errorf("%s scheme: %d role pairs below Lc %v, or Lc %v for non-text pairs", m, len(failures), thresholds.BodyText, thresholds.NonText))
+			}
+			for _, result := range report.Results {
+				fmt.Fprintf(w, "%-5s %-46s Lc %6.1f  body %-4s large %-4s non-text %s\n", m, result.ContrastPair,
+					result.Lc, pass(result.BodyText), pass(result.LargeText), pass(result.NonText))
+			}
+			continue
+		}
+		report, err := all[m].AuditStrict()
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s scheme: %w", m, err))
+		}
+		for _, result := range report.Results {
+			fmt.Fprintf(w, "%-5s %-46s %5.2f:1  AA %-4s AA large %-4s AAA %-4s AAA large %s\n", m, result.ContrastPair,
+				result.Ratio, pass(result.AA), pass(result.AALarge), pass(result.AAA), pass(result.AAALarge))
+		}
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	return errors.Join(errs...)
+}
+
+// pass formats the result of a contrast check.
+func pass(ok bool) string {
+	if ok {
+		return "ok"
+	}
+	return "FAIL"
+}
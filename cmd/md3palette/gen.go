@@ -0,0 +1,143 @@
+// Copyright (c) 2023 https://github.com/gio-eui
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR a PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+//
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/gio-eui/md3-palettes/palette"
+)
+
+// gen writes a palette in the requested format.
+func gen(args []string) error {
+	fs := flag.NewFlagSet("gen", flag.ExitOnError)
+	var pf paletteFlags
+	pf.register(fs)
+	format := fs.String("format", "css", "output format: css, json, tokens, android, dart or go")
+	output := fs.String("o", "", "output file, or output directory for the android format (default standard output)")
+	darkSelector := fs.String("dark-selector", "", "css: selector of the dark scheme (default a prefers-color-scheme media query)")
+	themeName := fs.String("theme", "AppTheme", "android: name of the themes")
+	pkg := fs.String("package", defaultPackage(), "go: package name (default $GOPACKAGE when run by go generate)")
+	name := fs.String("name", "Palette", "go: name of the palette variable")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "usage: md3palette gen [flags]")
+		fmt.Fprintln(fs.Output(), "       md3palette gen -format go [flags] Name=#RRGGBB...")
+		fs.PrintDefaults()
+	}
+	_ = fs.Parse(args)
+
+	if *format == "go" && fs.NArg() > 0 {
+		named, err := namedPalettes(&pf, fs.Args())
+		if err != nil {
+			return err
+		}
+		return writeOutput(*output, func(w io.Writer) error { return palette.WriteGo(w, *pkg, named) })
+	}
+	if fs.NArg() > 0 {
+		return fmt.Errorf("unexpected arguments: %v", fs.Args())
+	}
+
+	p, err := pf.palette()
+	if err != nil {
+		return err
+	}
+
+	if *format == "android" {
+		if *output == "" {
+			return fmt.Errorf("the android format needs an output directory")
+		}
+		return p.WriteAndroidResources(*output, palette.AndroidOptions{ThemeName: *themeName})
+	}
+
+	var write func(w io.Writer) error
+	switch *format {
+	case "css":
+		write = func(w io.Writer) error { return p.WriteCSS(w, palette.CSSOptions{DarkSelector: *darkSelector}) }
+	case "json":
+		write = func(w io.Writer) error { return writeJSON(w, p) }
+	case "tokens":
+		write = p.WriteDesignTokens
+	case "dart":
+		write = p.WriteDart
+	case "go":
+		write = func(w io.Writer) error { return palette.WriteGo(w, *pkg, map[string]*palette.Palette{*name: p}) }
+	default:
+		return fmt.Errorf("unknown format %q", *format)
+	}
+	return writeOutput(*output, write)
+}
+
+// writeOutput writes the output of write to the output file, or to the standard output
+// when output is empty. Nothing is written if write fails.
+func writeOutput(output string, write func(w io.Writer) error) error {
+	var b bytes.Buffer
+	if err := write(&b); err != nil {
+		return err
+	}
+	if output == "" {
+		_, err := os.Stdout.Write(b.Bytes())
+		return err
+	}
+	return os.WriteFile(output, b.Bytes(), 0o644)
+}
+
+// namedPalettes returns the palettes of arguments formatted as Name=#RRGGBB, derived from
+// their seed color with the variant and contrast level of the flags.
+func namedPalettes(pf *paletteFlags, args []string) (map[string]*palette.Palette, error) {
+	named := make(map[string]*palette.Palette, len(args))
+	for _, arg := range args {
+		name, hex, ok := strings.Cut(arg, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid seed %q, expected Name=#RRGGBB", arg)
+		}
+		p, err := pf.paletteFromSeed(hex)
+		if err != nil {
+			return nil, err
+		}
+		named[name] = p
+	}
+	return named, nil
+}
+
+// defaultPackage returns the package name set by go generate, or "themes".
+func defaultPackage() string {
+	if pkg := os.Getenv("GOPACKAGE"); pkg != "" {
+		return pkg
+	}
+	return "themes"
+}
+
+// writeJSON writes a palette as indented JSON.
+func writeJSON(w io.Writer, p *palette.Palette) error {
+	data, err := json.MarshalIndent(p, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(append(data, '\n'))
+	return err
+}
@@ -0,0 +1,162 @@
+// Copyright (c) 2023 https://github.com/gio-eui
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR a PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+//
+// SPDX-License-Identifier: MIT
+
+// Command md3palette generates, shows and checks Material Design 3 palettes.
+//
+// Usage:
+//
+//	md3palette gen [flags]    write a palette as css, json, tokens, android, dart or go
+//	md3palette show [flags]   print the roles of a palette with truecolor swatches
+//	md3palette check [flags]  audit the contrast of the role pairs of a palette
+//
+// The palette is derived from a seed color, or read from a JSON file written by gen.
+// With the go format, gen can also be run by go generate to declare precomputed palettes,
+// each argument naming a palette and its seed color as Name=#RRGGBB:
+//
+//	//go:generate go run github.com/gio-eui/md3-palettes/cmd/md3palette gen -format go -o themes_gen.go Brand=#6750A4
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/gio-eui/md3-palettes/palette"
+	"github.com/gio-eui/md3-palettes/scheme"
+)
+
+// commands are the subcommands of md3palette.
+var commands = map[string]func(args []string) error{
+	"gen":   gen,
+	"show":  show,
+	"check": check,
+}
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(2)
+	}
+	command, ok := commands[os.Args[1]]
+	if !ok {
+		usage()
+		os.Exit(2)
+	}
+	if err := command(os.Args[2:]); err != nil {
+		fmt.Fprintln(os.Stderr, "md3palette:", err)
+		os.Exit(1)
+	}
+}
+
+// usage prints the usage of md3palette.
+func usage() {
+	fmt.Fprintln(os.Stderr, "usage: md3palette gen|show|check [flags]")
+	fmt.Fprintln(os.Stderr, "run md3palette <command> -h for the flags of a command")
+}
+
+// paletteFlags are the flags selecting the palette of a command.
+type paletteFlags struct {
+	seed     string
+	variant  string
+	contrast string
+	input    string
+}
+
+// register registers the flags in fs.
+func (f *paletteFlags) register(fs *flag.FlagSet) {
+	fs.StringVar(&f.seed, "seed", "#6750A4", "seed color, as #RRGGBB")
+	fs.StringVar(&f.variant, "variant", "tonalspot", "scheme variant: tonalspot, vibrant, expressive, fidelity, content, monochrome, neutral, rainbow or fruitsalad")
+	fs.StringVar(&f.contrast, "contrast", "standard", "contrast level: reduced, standard, medium, high, or a number from -1 to 1")
+	fs.StringVar(&f.input, "input", "", "JSON palette file to read instead of deriving the palette from the seed")
+}
+
+// palette returns the palette selected by the flags.
+func (f *paletteFlags) palette() (*palette.Palette, error) {
+	if f.input != "" {
+		data, err := os.ReadFile(f.input)
+		if err != nil {
+			return nil, err
+		}
+		p := &palette.Palette{}
+		if err := json.Unmarshal(data, p); err != nil {
+			return nil, err
+		}
+		return p, nil
+	}
+
+	return f.paletteFromSeed(f.seed)
+}
+
+// paletteFromSeed returns the palette derived from a seed color, as #RRGGBB, with the
+// variant and contrast level selected by the flags.
+func (f *paletteFlags) paletteFromSeed(hex string) (*palette.Palette, error) {
+	seed, err := scheme.ParseHex(hex)
+	if err != nil {
+		return nil, err
+	}
+	variant, err := scheme.ParseVariant(f.variant)
+	if err != nil {
+		return nil, err
+	}
+	contrast, err := parseContrast(f.contrast)
+	if err != nil {
+		return nil, err
+	}
+	seed.A = 0xff
+	return palette.NewPaletteFromSeed(scheme.Argb(seed), variant, contrast), nil
+}
+
+// parseContrast parses a contrast level, either named or as a number.
+func parseContrast(s string) (float64, error) {
+	switch strings.ToLower(s) {
+	case "reduced":
+		return scheme.ContrastReduced, nil
+	case "standard":
+		return scheme.ContrastStandard, nil
+	case "medium":
+		return scheme.ContrastMedium, nil
+	case "high":
+		return scheme.ContrastHigh, nil
+	}
+	level, err := strconv.ParseFloat(s, 64)
+	if err != nil || level < -1 || level > 1 {
+		return 0, fmt.Errorf("invalid contrast level %q", s)
+	}
+	return level, nil
+}
+
+// schemes returns the schemes of the palette selected by mode, which is light, dark or both,
+// keyed by their mode.
+func schemes(p *palette.Palette, mode string) ([]string, map[string]*scheme.Scheme, error) {
+	snap := p.Snapshot()
+	all := map[string]*scheme.Scheme{"light": snap.Light, "dark": snap.Dark}
+	switch mode {
+	case "both":
+		return []string{"light", "dark"}, all, nil
+	case "light", "dark":
+		return []string{mode}, all, nil
+	default:
+		return nil, nil, fmt.Errorf("invalid mode %q", mode)
+	}
+}
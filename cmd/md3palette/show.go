@@ -0,0 +1,66 @@
+// Copyright (c) 2023 https://github.com/gio-eui
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR a PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+//
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/gio-eui/md3-palettes/scheme"
+)
+
+// show prints the roles of a palette with ANSI truecolor swatches.
+func show(args []string) error {
+	fs := flag.NewFlagSet("show", flag.ExitOnError)
+	var pf paletteFlags
+	pf.register(fs)
+	mode := fs.String("mode", "both", "schemes to show: light, dark or both")
+	_ = fs.Parse(args)
+
+	p, err := pf.palette()
+	if err != nil {
+		return err
+	}
+	modes, all, err := schemes(p, *mode)
+	if err != nil {
+		return err
+	}
+
+	w := bufio.NewWriter(os.Stdout)
+	for i, m := range modes {
+		if i > 0 {
+			fmt.Fprintln(w)
+		}
+		s := all[m]
+		fmt.Fprintf(w, "%s scheme\n", m)
+		for _, role := range scheme.Roles() {
+			if role.IsCustom() && !s.HasCustomColor() {
+				continue
+			}
+			c, _ := s.Color(role)
+			fmt.Fprintf(w, "\x1b[48;2;%d;%d;%dm      \x1b[0m %-10s %s\n", c.R, c.G, c.B, scheme.Hex(c), role)
+		}
+	}
+	return w.Flush()
+}
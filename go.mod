@@ -2,6 +2,17 @@ module github.com/gio-eui/md3-palettes
 
 go 1.20
 
-require github.com/gio-eui/md3-colors v0.0.0-20230728085454-a52d0187aad9
+require (
+	gioui.org v0.2.0
+	github.com/gio-eui/md3-colors v0.0.0-20230728085454-a52d0187aad9
+)
+
+require (
+	github.com/go-text/typesetting v0.0.0-20230803102845-24e03d8b5372 // indirect
+	golang.org/x/exp v0.0.0-20221012211006-4de253d81b95 // indirect
+	golang.org/x/exp/shiny v0.0.0-20220827204233-334a2380cb91 // indirect
+	golang.org/x/image v0.5.0 // indirect
+	golang.org/x/text v0.7.0 // indirect
+)
 
 //replace github.com/gio-eui/md3-colors => ../md3-colors
@@ -0,0 +1,209 @@
+// Copyright (c) 2023 https://github.com/gio-eui
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR a PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+//
+// SPDX-License-Identifier: MIT
+
+package palette
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode"
+
+	"github.com/gio-eui/md3-palettes/scheme"
+)
+
+// AndroidOptions configures the Android resources export of a palette.
+type AndroidOptions struct {
+	// ThemeName is the name of the themes, suffixed by ".Light" and ".Dark".
+	// Defaults to "AppTheme".
+	ThemeName string
+}
+
+// WriteAndroidResources writes the colors.xml, themes.xml and, when the palette has a
+// custom color, attrs.xml resources of the palette in the values directory of dir.
+func (p *Palette) WriteAndroidResources(dir string, opts AndroidOptions) error {
+	snap := p.Snapshot()
+	values := filepath.Join(dir, "values")
+	if err := os.MkdirAll(values, 0o755); err != nil {
+		return err
+	}
+	// Every file is written from the same snapshot, so that they match even if the palette
+	// changes meanwhile.
+	files := map[string]func(io.Writer) error{
+		"colors.xml": func(w io.Writer) error { return writeAndroidColors(w, snap) },
+		"themes.xml": func(w io.Writer) error { return writeAndroidThemes(w, snap, opts) },
+	}
+	if snap.Light.HasCustomColor() {
+		files["attrs.xml"] = writeAndroidAttrs
+	}
+	for name, write := range files {
+		f, err := os.Create(filepath.Join(values, name))
+		if err != nil {
+			return err
+		}
+		err = write(f)
+		if cerr := f.Close(); err == nil {
+			err = cerr
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WriteAndroidColors writes the light and dark schemes of the palette as an Android
+// colors.xml resource, with one color per role named like md_theme_light_on_primary.
+func (p *Palette) WriteAndroidColors(w io.Writer) error {
+	return writeAndroidColors(w, p.Snapshot())
+}
+
+// WriteAndroidThemes writes an Android themes.xml resource with a light theme derived from
+// Theme.Material3.Light.NoActionBar and a dark theme derived from Theme.Material3.Dark.NoActionBar,
+// whose color attributes refer to the colors written by WriteAndroidColors.
+// The custom roles use the attributes declared by WriteAndroidAttrs.
+func (p *Palette) WriteAndroidThemes(w io.Writer, opts AndroidOptions) error {
+	return writeAndroidThemes(w, p.Snapshot(), opts)
+}
+
+// WriteAndroidAttrs writes an Android attrs.xml resource declaring the theme attributes of
+// the custom roles, which are not part of the Material Components library.
+func (p *Palette) WriteAndroidAttrs(w io.Writer) error {
+	return writeAndroidAttrs(w)
+}
+
+// writeAndroidColors writes the colors.xml resource of a snapshot.
+func writeAndroidColors(w io.Writer, snap Snapshot) error {
+	bw := bufio.NewWriter(w)
+	fmt.Fprintln(bw, `<?xml version="1.0" encoding="utf-8"?>`)
+	fmt.Fprintln(bw, "<resources>")
+	writeAndroidSchemeColors(bw, snap.Light, "light")
+	writeAndroidSchemeColors(bw, snap.Dark, "dark")
+	fmt.Fprintln(bw, "</resources>")
+	return bw.Flush()
+}
+
+// writeAndroidThemes writes the themes.xml resource of a snapshot.
+func writeAndroidThemes(w io.Writer, snap Snapshot, opts AndroidOptions) error {
+	name := opts.ThemeName
+	if name == "" {
+		name = "AppTheme"
+	}
+
+	bw := bufio.NewWriter(w)
+	fmt.Fprintln(bw, `<?xml version="1.0" encoding="utf-8"?>`)
+	fmt.Fprintln(bw, "<resources>")
+	writeAndroidSchemeTheme(bw, snap.Light, name+".Light", "Theme.Material3.Light.NoActionBar", "light")
+	fmt.Fprintln(bw)
+	writeAndroidSchemeTheme(bw, snap.Dark, name+".Dark", "Theme.Material3.Dark.NoActionBar", "dark")
+	fmt.Fprintln(bw, "</resources>")
+	return bw.Flush()
+}
+
+// writeAndroidAttrs writes the attrs.xml resource.
+func writeAndroidAttrs(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	fmt.Fprintln(bw, `<?xml version="1.0" encoding="utf-8"?>`)
+	fmt.Fprintln(bw, "<resources>")
+	for _, role := range scheme.Roles() {
+		if role.IsCustom() {
+			attr, _ := androidAttr(role)
+			fmt.Fprintf(bw, "    <attr name=\"%s\" format=\"color\" />\n", attr)
+		}
+	}
+	fmt.Fprintln(bw, "</resources>")
+	return bw.Flush()
+}
+
+// writeAndroidSchemeColors writes the color resources of a scheme.
+func writeAndroidSchemeColors(w io.Writer, s *scheme.Scheme, mode string) {
+	for _, role := range scheme.Roles() {
+		if role.IsCustom() && !s.HasCustomColor() {
+			continue
+		}
+		c, _ := s.Color(role)
+		fmt.Fprintf(w, "    <color name=\"%s\">#%02X%02X%02X%02X</color>\n", androidColorName(role, mode), c.A, c.R, c.G, c.B)
+	}
+}
+
+// writeAndroidSchemeTheme writes the style of a scheme.
+func writeAndroidSchemeTheme(w io.Writer, s *scheme.Scheme, name string, parent string, mode string) {
+	fmt.Fprintf(w, "    <style name=\"%s\" parent=\"%s\">\n", xmlEscape(name), parent)
+	for _, role := range scheme.Roles() {
+		if role.IsCustom() && !s.HasCustomColor() {
+			continue
+		}
+		attr, ok := androidAttr(role)
+		if !ok {
+			continue
+		}
+		fmt.Fprintf(w, "        <item name=\"%s\">@color/%s</item>\n", attr, androidColorName(role, mode))
+	}
+	fmt.Fprintln(w, "    </style>")
+}
+
+// androidColorName returns the name of the color resource of a role, such as
+// md_theme_light_on_primary_container.
+func androidColorName(role scheme.Role, mode string) string {
+	var b strings.Builder
+	b.WriteString("md_theme_")
+	b.WriteString(mode)
+	b.WriteByte('_')
+	for _, c := range string(role) {
+		if unicode.IsUpper(c) {
+			b.WriteByte('_')
+			c = unicode.ToLower(c)
+		}
+		b.WriteRune(c)
+	}
+	return b.String()
+}
+
+// androidAttr returns the theme attribute of a role, such as colorOnPrimaryContainer.
+// The shadow, scrim and surface tint roles have no theme attribute.
+func androidAttr(role scheme.Role) (string, bool) {
+	switch role {
+	case scheme.RoleBackground:
+		return "android:colorBackground", true
+	case scheme.RoleInversePrimary:
+		return "colorPrimaryInverse", true
+	case scheme.RoleInverseSurface:
+		return "colorSurfaceInverse", true
+	case scheme.RoleInverseOnSurface:
+		return "colorOnSurfaceInverse", true
+	case scheme.RoleShadow, scheme.RoleScrim, scheme.RoleShadowTint:
+		return "", false
+	}
+	name := string(role)
+	return "color" + strings.ToUpper(name[:1]) + name[1:], true
+}
+
+// xmlEscape escapes a string for use in XML text and attributes.
+func xmlEscape(s string) string {
+	var b bytes.Buffer
+	_ = xml.EscapeText(&b, []byte(s))
+	return b.String()
+}
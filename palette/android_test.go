@@ -0,0 +1,131 @@
+// Copyright (c) 2023 https://github.com/gio-eui
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR a PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+//
+// SPDX-License-Identifier: MIT
+package palette
+
+import (
+	"bytes"
+	"flag"
+	"image/color"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gio-eui/md3-palettes/scheme"
+)
+
+var update = flag.Bool("update", false, "update the golden files in testdata")
+
+// goldenPalette returns a palette whose colors are set role by role, so that the exported
+// files do not depend on the color science.
+func goldenPalette() *Palette {
+	light, dark := &scheme.Scheme{}, &scheme.Scheme{}
+	for i, role := range scheme.Roles() {
+		n := uint8(i)
+		light.SetColor(role, color.NRGBA{R: n, G: 0x80 + n, B: 0xff - n, A: 0xff})
+		dark.SetColor(role, color.NRGBA{R: 0xff - n, G: 0x7f - n, B: n, A: 0xff})
+	}
+	return NewPaletteFromSchemes(light, dark)
+}
+
+// checkGolden compares the output of write with the golden file testdata/name, or updates the
+// golden file when the -update flag is set.
+func checkGolden(t *testing.T, name string, write func(io.Writer) error) {
+	t.Helper()
+	var got bytes.Buffer
+	if err := write(&got); err != nil {
+		t.Fatal(err)
+	}
+	golden := filepath.Join("testdata", name)
+	if *update {
+		if err := os.WriteFile(golden, got.Bytes(), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+	want, err := os.ReadFile(golden)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got.Bytes(), want) {
+		t.Errorf("%s differs from %s:\n%s", name, golden, got.String())
+	}
+}
+
+func TestWriteAndroidColors(t *testing.T) {
+	checkGolden(t, "colors.xml", goldenPalette().WriteAndroidColors)
+}
+
+func TestWriteAndroidThemes(t *testing.T) {
+	p := goldenPalette()
+	checkGolden(t, "themes.xml", func(w io.Writer) error {
+		return p.WriteAndroidThemes(w, AndroidOptions{ThemeName: "Theme.App"})
+	})
+}
+
+func TestWriteAndroidAttrs(t *testing.T) {
+	checkGolden(t, "attrs.xml", goldenPalette().WriteAndroidAttrs)
+}
+
+func TestWriteAndroidResources(t *testing.T) {
+	dir := t.TempDir()
+	if err := goldenPalette().WriteAndroidResources(dir, AndroidOptions{ThemeName: "Theme.App"}); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"colors.xml", "themes.xml", "attrs.xml"} {
+		checkGolden(t, name, func(w io.Writer) error {
+			data, err := os.ReadFile(filepath.Join(dir, "values", name))
+			if err != nil {
+				return err
+			}
+			_, err = w.Write(data)
+			return err
+		})
+	}
+}
+
+func TestWriteAndroidResourcesWithoutCustomColor(t *testing.T) {
+	p := goldenPalette()
+	snap := p.Snapshot()
+	light, dark := snap.Light.Clone(), snap.Dark.Clone()
+	light.Custom, dark.Custom = color.NRGBA{}, color.NRGBA{}
+	p = NewPaletteFromSchemes(light, dark)
+
+	dir := t.TempDir()
+	if err := p.WriteAndroidResources(dir, AndroidOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "values", "attrs.xml")); !os.IsNotExist(err) {
+		t.Errorf("attrs.xml written without a custom color: %v", err)
+	}
+	for _, name := range []string{"colors.xml", "themes.xml"} {
+		data, err := os.ReadFile(filepath.Join(dir, "values", name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if bytes.Contains(data, []byte("custom")) || bytes.Contains(data, []byte("Custom")) {
+			t.Errorf("%s has custom roles without a custom color:\n%s", name, data)
+		}
+		if name == "themes.xml" && !bytes.Contains(data, []byte(`<style name="AppTheme.Light"`)) {
+			t.Errorf("themes.xml does not use the default theme name:\n%s", data)
+		}
+	}
+}
@@ -0,0 +1,37 @@
+// Copyright (c) 2023 https://github.com/gio-eui
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR a PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+//
+// SPDX-License-Identifier: MIT
+
+package palette
+
+import "github.com/gio-eui/md3-palettes/scheme"
+
+// Audit checks the WCAG 2 contrast ratio of every role pair of the light and dark schemes.
+func (p *Palette) Audit() (light *scheme.AuditReport, dark *scheme.AuditReport) {
+	snap := p.Snapshot()
+	return snap.Light.Audit(), snap.Dark.Audit()
+}
+
+// AuditAPCA checks the APCA lightness contrast of every role pair of the light and dark
+// schemes against the thresholds, such as scheme.DefaultAPCAThresholds.
+func (p *Palette) AuditAPCA(thresholds scheme.APCAThresholds) (light *scheme.APCAReport, dark *scheme.APCAReport) {
+	snap := p.Snapshot()
+	return snap.Light.AuditAPCA(thresholds), snap.Dark.AuditAPCA(thresholds)
+}
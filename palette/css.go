@@ -0,0 +1,66 @@
+// Copyright (c) 2023 https://github.com/gio-eui
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR a PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+//
+// SPDX-License-Identifier: MIT
+
+package palette
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+)
+
+// CSSOptions configures the CSS export of a palette.
+type CSSOptions struct {
+	// LightSelector is the selector of the light scheme. Defaults to ":root".
+	LightSelector string
+	// DarkSelector is the selector of the dark scheme, such as ".dark".
+	// When empty, the dark scheme applies to the light selector when the user prefers
+	// a dark color scheme, through a prefers-color-scheme media query.
+	DarkSelector string
+}
+
+// WriteCSS writes the light and dark schemes of the palette as CSS custom properties,
+// named after the MD3 tokens such as --md-sys-color-primary, as written by Scheme.WriteCSS.
+func (p *Palette) WriteCSS(w io.Writer, opts CSSOptions) error {
+	snap := p.Snapshot()
+	lightSelector := opts.LightSelector
+	if lightSelector == "" {
+		lightSelector = ":root"
+	}
+
+	bw := bufio.NewWriter(w)
+	if err := snap.Light.WriteCSS(bw, lightSelector); err != nil {
+		return err
+	}
+	fmt.Fprintln(bw)
+	if opts.DarkSelector != "" {
+		if err := snap.Dark.WriteCSS(bw, opts.DarkSelector); err != nil {
+			return err
+		}
+	} else {
+		fmt.Fprintln(bw, "@media (prefers-color-scheme: dark) {")
+		if err := snap.Dark.WriteCSSRule(bw, lightSelector, "  "); err != nil {
+			return err
+		}
+		fmt.Fprintln(bw, "}")
+	}
+	return bw.Flush()
+}
@@ -0,0 +1,143 @@
+// Copyright (c) 2023 https://github.com/gio-eui
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR a PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+//
+// SPDX-License-Identifier: MIT
+
+package palette
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+
+	"github.com/gio-eui/md3-palettes/scheme"
+)
+
+// WriteDart writes the light and dark schemes of the palette as a Dart file declaring the
+// Flutter ColorScheme constants lightColorScheme and darkColorScheme. When the palette has
+// a custom color, the custom roles are declared as the CustomColors ThemeExtension, with the
+// constants lightCustomColors and darkCustomColors.
+func (p *Palette) WriteDart(w io.Writer) error {
+	snap := p.Snapshot()
+	bw := bufio.NewWriter(w)
+	fmt.Fprintln(bw, "// Code generated by md3-palettes. DO NOT EDIT.")
+	fmt.Fprintln(bw)
+	fmt.Fprintln(bw, "import 'package:flutter/material.dart';")
+	fmt.Fprintln(bw)
+	writeDartColorScheme(bw, snap.Light, "lightColorScheme", "Brightness.light")
+	fmt.Fprintln(bw)
+	writeDartColorScheme(bw, snap.Dark, "darkColorScheme", "Brightness.dark")
+	if snap.Light.HasCustomColor() {
+		fmt.Fprintln(bw)
+		writeDartCustomColors(bw)
+		fmt.Fprintln(bw)
+		writeDartCustomColorsConst(bw, snap.Light, "lightCustomColors")
+		fmt.Fprintln(bw)
+		writeDartCustomColorsConst(bw, snap.Dark, "darkCustomColors")
+	}
+	return bw.Flush()
+}
+
+// writeDartColorScheme writes a ColorScheme constant with the colors of a scheme.
+func writeDartColorScheme(w io.Writer, s *scheme.Scheme, name string, brightness string) {
+	fmt.Fprintf(w, "const ColorScheme %s = ColorScheme(\n", name)
+	fmt.Fprintf(w, "  brightness: %s,\n", brightness)
+	for _, role := range scheme.Roles() {
+		if role.IsCustom() {
+			continue
+		}
+		c, _ := s.Color(role)
+		fmt.Fprintf(w, "  %s: %s,\n", dartParameter(role), dartColor(scheme.Argb(c)))
+	}
+	fmt.Fprintln(w, ");")
+}
+
+// writeDartCustomColors writes the CustomColors ThemeExtension class.
+func writeDartCustomColors(w io.Writer) {
+	var custom []string
+	for _, role := range scheme.Roles() {
+		if role.IsCustom() {
+			custom = append(custom, string(role))
+		}
+	}
+
+	fmt.Fprintln(w, "@immutable")
+	fmt.Fprintln(w, "class CustomColors extends ThemeExtension<CustomColors> {")
+	fmt.Fprintln(w, "  const CustomColors({")
+	for _, name := range custom {
+		fmt.Fprintf(w, "    required this.%s,\n", name)
+	}
+	fmt.Fprintln(w, "  });")
+	fmt.Fprintln(w)
+	for _, name := range custom {
+		fmt.Fprintf(w, "  final Color? %s;\n", name)
+	}
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "  @override")
+	fmt.Fprintln(w, "  CustomColors copyWith({")
+	for _, name := range custom {
+		fmt.Fprintf(w, "    Color? %s,\n", name)
+	}
+	fmt.Fprintln(w, "  }) {")
+	fmt.Fprintln(w, "    return CustomColors(")
+	for _, name := range custom {
+		fmt.Fprintf(w, "      %s: %s ?? this.%s,\n", name, name, name)
+	}
+	fmt.Fprintln(w, "    );")
+	fmt.Fprintln(w, "  }")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "  @override")
+	fmt.Fprintln(w, "  CustomColors lerp(ThemeExtension<CustomColors>? other, double t) {")
+	fmt.Fprintln(w, "    if (other is! CustomColors) {")
+	fmt.Fprintln(w, "      return this;")
+	fmt.Fprintln(w, "    }")
+	fmt.Fprintln(w, "    return CustomColors(")
+	for _, name := range custom {
+		fmt.Fprintf(w, "      %s: Color.lerp(%s, other.%s, t),\n", name, name, name)
+	}
+	fmt.Fprintln(w, "    );")
+	fmt.Fprintln(w, "  }")
+	fmt.Fprintln(w, "}")
+}
+
+// writeDartCustomColorsConst writes a CustomColors constant with the custom colors of a scheme.
+func writeDartCustomColorsConst(w io.Writer, s *scheme.Scheme, name string) {
+	fmt.Fprintf(w, "const CustomColors %s = CustomColors(\n", name)
+	for _, role := range scheme.Roles() {
+		if !role.IsCustom() {
+			continue
+		}
+		c, _ := s.Color(role)
+		fmt.Fprintf(w, "  %s: %s,\n", role, dartColor(scheme.Argb(c)))
+	}
+	fmt.Fprintln(w, ");")
+}
+
+// dartParameter returns the ColorScheme parameter of a role.
+func dartParameter(role scheme.Role) string {
+	if role == scheme.RoleInverseOnSurface {
+		return "onInverseSurface"
+	}
+	return string(role)
+}
+
+// dartColor formats an argb color as a Dart Color.
+func dartColor(argb int) string {
+	return fmt.Sprintf("Color(0x%08X)", uint32(argb))
+}
@@ -0,0 +1,111 @@
+// Copyright (c) 2023 https://github.com/gio-eui
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR a PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+//
+// SPDX-License-Identifier: MIT
+
+package palette
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"go/token"
+	"image/color"
+	"io"
+	"reflect"
+	"sort"
+
+	"github.com/gio-eui/md3-palettes/scheme"
+)
+
+// WriteGo writes a Go source file of package pkg declaring the given palettes as literal
+// values, so that they can be embedded in an application without any color computation.
+// Each palette is declared under its name, along with its schemes suffixed by Light and Dark.
+// The literal schemes hold the colors of every role, but not the tonal palettes they were
+// derived from: they cannot be regenerated, for example with a different contrast level.
+// Extended colors are not written either.
+func WriteGo(w io.Writer, pkg string, named map[string]*Palette) error {
+	if !token.IsIdentifier(pkg) {
+		return fmt.Errorf("palette: invalid package name %q", pkg)
+	}
+	names := make([]string, 0, len(named))
+	for name := range named {
+		if !token.IsIdentifier(name) {
+			return fmt.Errorf("palette: invalid palette name %q", name)
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var b bytes.Buffer
+	fmt.Fprintln(&b, "// Code generated by md3-palettes. DO NOT EDIT.")
+	fmt.Fprintln(&b)
+	fmt.Fprintf(&b, "package %s\n\n", pkg)
+	fmt.Fprintln(&b, "import (")
+	fmt.Fprintln(&b, "\t\"image/color\"")
+	fmt.Fprintln(&b)
+	fmt.Fprintln(&b, "\t\"github.com/gio-eui/md3-palettes/palette\"")
+	fmt.Fprintln(&b, "\t\"github.com/gio-eui/md3-palettes/scheme\"")
+	fmt.Fprintln(&b, ")")
+	for _, name := range names {
+		snap := named[name].Snapshot()
+		fmt.Fprintln(&b)
+		fmt.Fprintf(&b, "// %s is a precomputed palette.\n", name)
+		fmt.Fprintf(&b, "var %s = &palette.Palette{\n", name)
+		fmt.Fprintf(&b, "\tLight: %sLight,\n", name)
+		fmt.Fprintf(&b, "\tDark: %sDark,\n", name)
+		if snap.IsDark {
+			fmt.Fprintf(&b, "\tActive: %sDark,\n", name)
+			fmt.Fprintln(&b, "\tIsDark: true,")
+		} else {
+			fmt.Fprintf(&b, "\tActive: %sLight,\n", name)
+		}
+		fmt.Fprintf(&b, "\tContrastLevel: %v,\n", snap.ContrastLevel)
+		fmt.Fprintln(&b, "}")
+		writeGoScheme(&b, snap.Light, name+"Light", "light", name)
+		writeGoScheme(&b, snap.Dark, name+"Dark", "dark", name)
+	}
+
+	src, err := format.Source(b.Bytes())
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(src)
+	return err
+}
+
+// writeGoScheme writes a scheme as a literal value, with one field per color.
+func writeGoScheme(w io.Writer, s *scheme.Scheme, name string, mode string, paletteName string) {
+	fmt.Fprintln(w)
+	fmt.Fprintf(w, "// %s is the %s scheme of %s.\n", name, mode, paletteName)
+	fmt.Fprintf(w, "var %s = &scheme.Scheme{\n", name)
+	v := reflect.ValueOf(s).Elem()
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Type().Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		c, ok := v.Field(i).Interface().(color.NRGBA)
+		if !ok || c == (color.NRGBA{}) {
+			continue
+		}
+		fmt.Fprintf(w, "\t%s: color.NRGBA{R: 0x%02x, G: 0x%02x, B: 0x%02x, A: 0x%02x},\n", field.Name, c.R, c.G, c.B, c.A)
+	}
+	fmt.Fprintln(w, "}")
+}
@@ -0,0 +1,94 @@
+// Copyright (c) 2023 https://github.com/gio-eui
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR a PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+//
+// SPDX-License-Identifier: MIT
+
+package palette
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"regexp"
+	"testing"
+
+	"github.com/gio-eui/md3-palettes/scheme"
+)
+
+func TestWriteGo(t *testing.T) {
+	named := map[string]*Palette{
+		"Brand": testPalette(),
+		"Alt":   NewPaletteFromSeed(0xff006c4c, scheme.VariantVibrant, 0),
+	}
+	var b bytes.Buffer
+	if err := WriteGo(&b, "themes", named); err != nil {
+		t.Fatal(err)
+	}
+	src := b.Bytes()
+
+	formatted, err := format.Source(src)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(formatted, src) {
+		t.Errorf("source is not gofmt-clean:\n%s", src)
+	}
+
+	f, err := parser.ParseFile(token.NewFileSet(), "themes_gen.go", src, parser.ParseComments)
+	if err != nil {
+		t.Fatalf("source does not parse: %v\n%s", err, src)
+	}
+	if f.Name.Name != "themes" {
+		t.Errorf("package %s, want themes", f.Name.Name)
+	}
+	var vars []string
+	for _, decl := range f.Decls {
+		if gen, ok := decl.(*ast.GenDecl); ok && gen.Tok == token.VAR {
+			for _, spec := range gen.Specs {
+				for _, name := range spec.(*ast.ValueSpec).Names {
+					vars = append(vars, name.Name)
+				}
+			}
+		}
+	}
+	want := []string{"Alt", "AltLight", "AltDark", "Brand", "BrandLight", "BrandDark"}
+	if fmt.Sprint(vars) != fmt.Sprint(want) {
+		t.Errorf("declared %v, want %v", vars, want)
+	}
+
+	dark := named["Brand"].Snapshot().Dark
+	primary := regexp.MustCompile(fmt.Sprintf(`\tPrimary: +color\.NRGBA\{R: 0x%02x, G: 0x%02x, B: 0x%02x, A: 0xff\}`,
+		dark.Primary.R, dark.Primary.G, dark.Primary.B))
+	if !primary.Match(src) {
+		t.Errorf("missing the primary color of BrandDark, %s", scheme.Hex(dark.Primary))
+	}
+}
+
+func TestWriteGoInvalidNames(t *testing.T) {
+	p := NewDefaultPalette()
+	if err := WriteGo(&bytes.Buffer{}, "my-themes", map[string]*Palette{"Brand": p}); err == nil {
+		t.Error("no error for an invalid package name")
+	}
+	if err := WriteGo(&bytes.Buffer{}, "themes", map[string]*Palette{"1Brand": p}); err == nil {
+		t.Error("no error for an invalid palette name")
+	}
+}
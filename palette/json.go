@@ -0,0 +1,84 @@
+// Copyright (c) 2023 https://github.com/gio-eui
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR a PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+//
+// SPDX-License-Identifier: MIT
+
+package palette
+
+import (
+	"encoding/json"
+	"errors"
+
+	"github.com/gio-eui/md3-palettes/scheme"
+)
+
+// paletteJSON is the JSON representation of a palette.
+type paletteJSON struct {
+	IsDark        bool           `json:"isDark"`
+	ContrastLevel float64        `json:"contrastLevel"`
+	Mode          string         `json:"mode"`
+	Light         *scheme.Scheme `json:"light"`
+	Dark          *scheme.Scheme `json:"dark"`
+}
+
+// MarshalJSON encodes the palette as JSON, with its light and dark schemes and its mode.
+func (p *Palette) MarshalJSON() ([]byte, error) {
+	snap := p.Snapshot()
+	return json.Marshal(paletteJSON{
+		IsDark:        snap.IsDark,
+		ContrastLevel: snap.ContrastLevel,
+		Mode:          snap.Mode.String(),
+		Light:         snap.Light,
+		Dark:          snap.Dark,
+	})
+}
+
+// UnmarshalJSON decodes a palette encoded by MarshalJSON, and activates the scheme it had.
+// The scheme of a system or scheduled mode is not resolved again: call UpdateMode to do so.
+func (p *Palette) UnmarshalJSON(data []byte) error {
+	var v paletteJSON
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	if v.Light == nil {
+		return errors.New("palette: missing light scheme")
+	}
+	if v.Dark == nil {
+		return errors.New("palette: missing dark scheme")
+	}
+	mode := ModeLight
+	if v.IsDark {
+		mode = ModeDark
+	}
+	if v.Mode != "" {
+		var err error
+		if mode, err = ParseMode(v.Mode); err != nil {
+			return err
+		}
+	}
+	p.update(func() {
+		p.Light = v.Light
+		p.Dark = v.Dark
+		p.IsDark = v.IsDark
+		p.ContrastLevel = v.ContrastLevel
+		p.Mode = mode
+		p.modeChanged()
+	})
+	return nil
+}
@@ -0,0 +1,98 @@
+// Copyright (c) 2023 https://github.com/gio-eui
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR a PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+//
+// SPDX-License-Identifier: MIT
+
+package palette
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/gio-eui/md3-palettes/scheme"
+)
+
+// testPalette returns a dark palette with a medium contrast and an extended color.
+func testPalette() *Palette {
+	p := NewPaletteFromSeed(0xff6750a4, scheme.VariantTonalSpot, 0.5)
+	p.SetExtendedColor("warning", 0xffffb300, true)
+	p.SwitchMode(true)
+	return p
+}
+
+func TestPaletteJSONRoundTrip(t *testing.T) {
+	p := testPalette()
+	data, err := json.Marshal(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	decoded := &Palette{}
+	if err := json.Unmarshal(data, decoded); err != nil {
+		t.Fatal(err)
+	}
+	want, got := p.Snapshot(), decoded.Snapshot()
+	if got.IsDark != want.IsDark || got.ContrastLevel != want.ContrastLevel || got.Mode != want.Mode {
+		t.Errorf("settings not kept: got dark=%t, contrast=%v, mode=%v, want dark=%t, contrast=%v, mode=%v",
+			got.IsDark, got.ContrastLevel, got.Mode, want.IsDark, want.ContrastLevel, want.Mode)
+	}
+	if got.Active != got.Dark {
+		t.Error("the dark scheme is not active after decoding a dark palette")
+	}
+	for _, role := range scheme.Roles() {
+		w, _ := want.Dark.Color(role)
+		if g, _ := got.Dark.Color(role); g != w {
+			t.Errorf("dark %s is %s after a round trip, want %s", role, scheme.Hex(g), scheme.Hex(w))
+		}
+	}
+	again, err := json.Marshal(decoded)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(again, data) {
+		t.Errorf("encoding changed after a round trip:\n%s\n%s", data, again)
+	}
+}
+
+func TestPaletteUnmarshalJSONErrors(t *testing.T) {
+	valid, err := json.Marshal(testPalette().Snapshot().Light)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name string
+		data string
+		err  string
+	}{
+		{"missing light scheme", `{"dark":` + string(valid) + `}`, "missing light scheme"},
+		{"missing dark scheme", `{"light":` + string(valid) + `}`, "missing dark scheme"},
+		{"unknown role", `{"light":{"colors":{"notARole":"#FFFFFF"}},"dark":` + string(valid) + `}`, `unknown color role "notARole"`},
+		{"bad hex color", `{"light":` + string(valid) + `,"dark":{"colors":{"primary":"#12"}}}`, `invalid hex color "#12"`},
+		{"unknown mode", `{"light":` + string(valid) + `,"dark":` + string(valid) + `,"mode":"dusk"}`, "dusk"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := json.Unmarshal([]byte(test.data), &Palette{})
+			if err == nil || !strings.Contains(err.Error(), test.err) {
+				t.Errorf("got error %v, want an error containing %q", err, test.err)
+			}
+		})
+	}
+}
@@ -0,0 +1,287 @@
+// Copyright (c) 2023 https://github.com/gio-eui
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR a PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+//
+// SPDX-License-Identifier: MIT
+
+package palette
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Mode is how the active scheme of a palette is chosen.
+type Mode int
+
+const (
+	ModeLight     Mode = iota // always the light scheme
+	ModeDark                  // always the dark scheme
+	ModeSystem                // the scheme preferred by the system, through a SystemModeProvider
+	ModeScheduled             // the light scheme between sunrise and sunset, the dark scheme otherwise
+)
+
+// modeNames are the names of the modes, as returned by String.
+var modeNames = map[Mode]string{
+	ModeLight:     "light",
+	ModeDark:      "dark",
+	ModeSystem:    "system",
+	ModeScheduled: "scheduled",
+}
+
+// String returns the name of the mode.
+func (m Mode) String() string {
+	if name, ok := modeNames[m]; ok {
+		return name
+	}
+	return fmt.Sprintf("Mode(%d)", int(m))
+}
+
+// ParseMode returns the mode with the given name, as returned by String.
+// The name is case-insensitive.
+func ParseMode(name string) (Mode, error) {
+	for m, n := range modeNames {
+		if strings.EqualFold(name, n) {
+			return m, nil
+		}
+	}
+	return 0, fmt.Errorf("palette: unknown mode %q", name)
+}
+
+// ErrSystemModeUnsupported is returned by the default SystemModeProvider on systems where the
+// preferred color scheme cannot be read.
+var ErrSystemModeUnsupported = errors.New("palette: system color scheme not supported")
+
+// SystemModeProvider reports whether the system prefers a dark color scheme.
+type SystemModeProvider interface {
+	IsDark() (bool, error)
+}
+
+// SystemModeProviderFunc is a function implementing SystemModeProvider.
+type SystemModeProviderFunc func() (bool, error)
+
+// IsDark calls f.
+func (f SystemModeProviderFunc) IsDark() (bool, error) {
+	return f()
+}
+
+// DefaultSystemModeProvider is the SystemModeProvider used by palettes that have none.
+// On Linux, it reads the GTK_THEME environment variable, the XDG desktop portal color-scheme
+// setting, the GNOME color-scheme setting and the GTK settings.ini files.
+var DefaultSystemModeProvider SystemModeProvider = systemModeProvider{}
+
+// Schedule holds the times of the day at which a scheduled palette switches to the light scheme,
+// at sunrise, and to the dark scheme, at sunset. Times are wall-clock times of the day in local
+// time, as durations since midnight in [0, 24h).
+type Schedule struct {
+	Sunrise time.Duration
+	Sunset  time.Duration
+}
+
+// DefaultSchedule is the schedule used by palettes that have none: light from 7:00 to 19:00.
+var DefaultSchedule = Schedule{Sunrise: 7 * time.Hour, Sunset: 19 * time.Hour}
+
+// IsDark reports whether the dark scheme is scheduled at t.
+func (s Schedule) IsDark(t time.Time) bool {
+	since := sinceMidnight(t)
+	if s.Sunrise <= s.Sunset {
+		return since < s.Sunrise || since >= s.Sunset
+	}
+	// Sunset before sunrise, such as in polar summers
+	return since >= s.Sunset && since < s.Sunrise
+}
+
+// Validate returns an error if the sunrise or sunset time is outside [0, 24h).
+func (s Schedule) Validate() error {
+	for _, at := range []time.Duration{s.Sunrise, s.Sunset} {
+		if at < 0 || at >= 24*time.Hour {
+			return fmt.Errorf("palette: invalid schedule time %v, want a time of the day in [0, 24h)", at)
+		}
+	}
+	return nil
+}
+
+// Next returns the first time after t at which the scheme switches. Switch times are wall-clock
+// times, like for IsDark, so that a day with a daylight saving time change has its switches at
+// the scheduled hours. The zero time is returned if the schedule is invalid.
+func (s Schedule) Next(t time.Time) time.Time {
+	if s.Validate() != nil {
+		return time.Time{}
+	}
+	y, m, d := t.Date()
+	var next time.Time
+	for day := d; day <= d+1; day++ {
+		for _, at := range []time.Duration{s.Sunrise, s.Sunset} {
+			hour, minute, sec, nsec := at/time.Hour, at%time.Hour/time.Minute, at%time.Minute/time.Second, at%time.Second
+			switchTime := time.Date(y, m, day, int(hour), int(minute), int(sec), int(nsec), t.Location())
+			if switchTime.After(t) && (next.IsZero() || switchTime.Before(next)) {
+				next = switchTime
+			}
+		}
+	}
+	return next
+}
+
+// sinceMidnight returns the duration since the local midnight of t.
+func sinceMidnight(t time.Time) time.Duration {
+	h, m, s := t.Clock()
+	return time.Duration(h)*time.Hour + time.Duration(m)*time.Minute + time.Duration(s)*time.Second +
+		time.Duration(t.Nanosecond())
+}
+
+// SetMode sets the mode of the palette and updates its active scheme accordingly.
+func (p *Palette) SetMode(mode Mode) error {
+	p.mu.Lock()
+	p.Mode = mode
+	p.modeChanged()
+	p.mu.Unlock()
+	return p.UpdateMode(time.Now())
+}
+
+// SetSystemModeProvider sets the provider reading the system color scheme in system mode,
+// and updates the active scheme accordingly.
+func (p *Palette) SetSystemModeProvider(provider SystemModeProvider) error {
+	p.mu.Lock()
+	p.SystemModeProvider = provider
+	p.modeChanged()
+	p.mu.Unlock()
+	return p.UpdateMode(time.Now())
+}
+
+// SetSchedule sets the switch times in scheduled mode, and updates the active scheme accordingly.
+// An error is returned, and the schedule kept, if a switch time is outside [0, 24h).
+func (p *Palette) SetSchedule(schedule Schedule) error {
+	if err := schedule.Validate(); err != nil {
+		return err
+	}
+	p.mu.Lock()
+	p.Schedule = schedule
+	p.modeChanged()
+	p.mu.Unlock()
+	return p.UpdateMode(time.Now())
+}
+
+// UpdateMode updates the active scheme of the palette according to its mode at the given time.
+// When the system color scheme cannot be read, the active scheme is kept and the error is returned.
+func (p *Palette) UpdateMode(now time.Time) error {
+	p.mu.Lock()
+	mode, provider, schedule := p.Mode, p.SystemModeProvider, p.schedule()
+	p.mu.Unlock()
+
+	// The system color scheme is read without holding the lock, as it may be slow
+	isDark, err := resolveMode(mode, provider, schedule, now)
+	if err != nil {
+		return err
+	}
+	p.update(func() {
+		// The mode may have changed meanwhile
+		if p.Mode == mode {
+			p.IsDark = isDark
+		}
+	})
+	return nil
+}
+
+// resolveMode returns whether the mode selects the dark scheme at the given time.
+func resolveMode(mode Mode, provider SystemModeProvider, schedule Schedule, now time.Time) (bool, error) {
+	switch mode {
+	case ModeLight:
+		return false, nil
+	case ModeDark:
+		return true, nil
+	case ModeSystem:
+		if provider == nil {
+			provider = DefaultSystemModeProvider
+		}
+		return provider.IsDark()
+	case ModeScheduled:
+		return schedule.IsDark(now), nil
+	default:
+		return false, fmt.Errorf("palette: unknown mode %d", int(mode))
+	}
+}
+
+// schedule returns the schedule of the palette, or the default schedule if it has none or
+// its schedule is invalid.
+func (p *Palette) schedule() Schedule {
+	if p.Schedule == (Schedule{}) || p.Schedule.Validate() != nil {
+		return DefaultSchedule
+	}
+	return p.Schedule
+}
+
+// WatchMode keeps the active scheme of the palette up to date with its mode until ctx is done:
+// at the switch times of the schedule in scheduled mode, and by polling the system color scheme
+// every interval in system mode. In light and dark modes, it only waits for ctx to be done or the
+// mode to change. Errors reading the system color scheme are ignored.
+// An error is returned if interval is not positive outside scheduled mode.
+func (p *Palette) WatchMode(ctx context.Context, interval time.Duration) error {
+	p.mu.Lock()
+	mode := p.Mode
+	p.mu.Unlock()
+	if interval <= 0 && mode != ModeScheduled {
+		return fmt.Errorf("palette: invalid watch interval %v in %s mode", interval, mode)
+	}
+
+	for {
+		now := time.Now()
+		_ = p.UpdateMode(now)
+
+		var timeout <-chan time.Time
+		p.mu.Lock()
+		mode, changed := p.Mode, p.modeChanges()
+		switch mode {
+		case ModeScheduled:
+			timeout = time.After(p.schedule().Next(now).Sub(now))
+		case ModeSystem:
+			if interval <= 0 {
+				p.mu.Unlock()
+				return fmt.Errorf("palette: invalid watch interval %v in %s mode", interval, mode)
+			}
+			timeout = time.After(interval)
+		}
+		p.mu.Unlock()
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-timeout:
+		case <-changed:
+		}
+	}
+}
+
+// modeChanges returns a channel closed when the mode, the system mode provider or the schedule
+// of the palette changes. It must be called with p.mu held.
+func (p *Palette) modeChanges() <-chan struct{} {
+	if p.modeChange == nil {
+		p.modeChange = make(chan struct{})
+	}
+	return p.modeChange
+}
+
+// modeChanged wakes up the callers of WatchMode, after a change of the mode, the system mode
+// provider or the schedule of the palette. It must be called with p.mu held.
+func (p *Palette) modeChanged() {
+	if p.modeChange != nil {
+		close(p.modeChange)
+		p.modeChange = nil
+	}
+}
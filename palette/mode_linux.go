@@ -0,0 +1,139 @@
+// Copyright (c) 2023 https://github.com/gio-eui
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR a PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+//
+// SPDX-License-Identifier: MIT
+
+//go:build linux
+
+package palette
+
+import (
+	"bufio"
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// systemModeProvider reads the color scheme preferred by the Linux desktop.
+type systemModeProvider struct{}
+
+// IsDark reads the GTK_THEME environment variable, then the XDG desktop portal color-scheme
+// setting, then the GNOME color-scheme setting, then the GTK settings.ini files, and returns
+// the first preference found.
+func (systemModeProvider) IsDark() (bool, error) {
+	if theme := os.Getenv("GTK_THEME"); theme != "" {
+		return strings.HasSuffix(strings.ToLower(theme), ":dark"), nil
+	}
+	if isDark, ok := portalIsDark(); ok {
+		return isDark, nil
+	}
+	if isDark, ok := gsettingsIsDark(); ok {
+		return isDark, nil
+	}
+	if isDark, ok := gtkSettingsIsDark(); ok {
+		return isDark, nil
+	}
+	return false, ErrSystemModeUnsupported
+}
+
+// portalIsDark reads the color-scheme setting of the XDG desktop portal, which is 1 when
+// the user prefers a dark appearance, 2 for a light one, and 0 when there is no preference.
+func portalIsDark() (isDark bool, ok bool) {
+	out, err := command("gdbus", "call", "--session",
+		"--dest", "org.freedesktop.portal.Desktop",
+		"--object-path", "/org/freedesktop/portal/desktop",
+		"--method", "org.freedesktop.portal.Settings.Read",
+		"org.freedesktop.appearance", "color-scheme")
+	if err != nil {
+		return false, false
+	}
+	switch {
+	case strings.Contains(out, "uint32 1"):
+		return true, true
+	case strings.Contains(out, "uint32 2"):
+		return false, true
+	}
+	return false, false
+}
+
+// gsettingsIsDark reads the color-scheme setting of GNOME.
+func gsettingsIsDark() (isDark bool, ok bool) {
+	out, err := command("gsettings", "get", "org.gnome.desktop.interface", "color-scheme")
+	if err != nil {
+		return false, false
+	}
+	switch strings.Trim(strings.TrimSpace(out), "'") {
+	case "prefer-dark":
+		return true, true
+	case "prefer-light":
+		return false, true
+	}
+	return false, false
+}
+
+// gtkSettingsIsDark reads the gtk-application-prefer-dark-theme and gtk-theme-name settings of
+// the GTK 4 and GTK 3 settings.ini files.
+func gtkSettingsIsDark() (isDark bool, ok bool) {
+	config := os.Getenv("XDG_CONFIG_HOME")
+	if config == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return false, false
+		}
+		config = filepath.Join(home, ".config")
+	}
+	for _, dir := range []string{"gtk-4.0", "gtk-3.0"} {
+		f, err := os.Open(filepath.Join(config, dir, "settings.ini"))
+		if err != nil {
+			continue
+		}
+		scanner := bufio.NewScanner(f)
+		for scanner.Scan() {
+			key, value, found := strings.Cut(scanner.Text(), "=")
+			if !found {
+				continue
+			}
+			key, value = strings.TrimSpace(key), strings.ToLower(strings.TrimSpace(value))
+			switch key {
+			case "gtk-application-prefer-dark-theme":
+				isDark, ok = value == "1" || value == "true", true
+			case "gtk-theme-name":
+				if !ok {
+					isDark, ok = strings.HasSuffix(value, "-dark"), true
+				}
+			}
+		}
+		f.Close()
+		if ok {
+			return isDark, true
+		}
+	}
+	return false, false
+}
+
+// command runs a command with a short timeout and returns its output.
+func command(name string, args ...string) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	out, err := exec.CommandContext(ctx, name, args...).Output()
+	return string(out), err
+}
@@ -0,0 +1,144 @@
+// Copyright (c) 2023 https://github.com/gio-eui
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR a PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+//
+// SPDX-License-Identifier: MIT
+
+package palette
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+	_ "time/tzdata"
+)
+
+func TestWatchModeInterval(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	for _, tc := range []struct {
+		mode  Mode
+		valid bool
+	}{
+		{ModeLight, false},
+		{ModeDark, false},
+		{ModeSystem, false},
+		{ModeScheduled, true},
+	} {
+		p := testPalette()
+		p.SetSystemModeProvider(SystemModeProviderFunc(func() (bool, error) { return false, nil }))
+		if err := p.SetMode(tc.mode); err != nil {
+			t.Fatal(err)
+		}
+		err := p.WatchMode(ctx, 0)
+		if tc.valid && !errors.Is(err, context.Canceled) {
+			t.Errorf("%s mode: WatchMode = %v, want %v", tc.mode, err, context.Canceled)
+		}
+		if !tc.valid && (err == nil || errors.Is(err, context.Canceled)) {
+			t.Errorf("%s mode: WatchMode = %v, want an interval error", tc.mode, err)
+		}
+	}
+}
+
+func TestWatchModeChange(t *testing.T) {
+	var calls atomic.Int32
+	p := testPalette()
+	p.SetSystemModeProvider(SystemModeProviderFunc(func() (bool, error) {
+		calls.Add(1)
+		return false, nil
+	}))
+	p.SwitchMode(true)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- p.WatchMode(ctx, time.Millisecond) }()
+
+	// In dark mode, the system color scheme is not polled.
+	time.Sleep(20 * time.Millisecond)
+	if n := calls.Load(); n != 0 {
+		t.Errorf("system color scheme read %d times in dark mode", n)
+	}
+
+	// Switching to system mode wakes WatchMode up, which then polls.
+	if err := p.SetMode(ModeSystem); err != nil {
+		t.Fatal(err)
+	}
+	deadline := time.Now().Add(time.Second)
+	for calls.Load() < 3 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	if n := calls.Load(); n < 3 {
+		t.Errorf("system color scheme read %d times in system mode", n)
+	}
+	if p.ActiveScheme() != p.Snapshot().Light {
+		t.Error("light system color scheme not applied")
+	}
+
+	cancel()
+	if err := <-done; !errors.Is(err, context.Canceled) {
+		t.Errorf("WatchMode = %v, want %v", err, context.Canceled)
+	}
+}
+
+func TestSetScheduleInvalid(t *testing.T) {
+	for _, schedule := range []Schedule{
+		{Sunrise: -time.Minute, Sunset: 19 * time.Hour},
+		{Sunrise: 7 * time.Hour, Sunset: 24 * time.Hour},
+		{Sunrise: -48 * time.Hour, Sunset: -24 * time.Hour},
+	} {
+		p := testPalette()
+		if err := p.SetSchedule(schedule); err == nil {
+			t.Errorf("SetSchedule(%+v) accepted", schedule)
+		}
+		if p.Schedule != (Schedule{}) {
+			t.Errorf("SetSchedule(%+v) changed the schedule", schedule)
+		}
+		if next := schedule.Next(time.Now()); !next.IsZero() {
+			t.Errorf("Next of %+v = %v, want the zero time", schedule, next)
+		}
+	}
+}
+
+func TestScheduleNextDST(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatal(err)
+	}
+	schedule := Schedule{Sunrise: 7 * time.Hour, Sunset: 19 * time.Hour}
+	for _, tc := range []struct {
+		now  time.Time
+		want time.Time
+	}{
+		// Clocks go forward at 2:00 and back at 2:00.
+		{time.Date(2023, 3, 12, 0, 30, 0, 0, loc), time.Date(2023, 3, 12, 7, 0, 0, 0, loc)},
+		{time.Date(2023, 3, 12, 7, 0, 0, 0, loc), time.Date(2023, 3, 12, 19, 0, 0, 0, loc)},
+		{time.Date(2023, 11, 5, 0, 30, 0, 0, loc), time.Date(2023, 11, 5, 7, 0, 0, 0, loc)},
+		{time.Date(2023, 11, 4, 20, 0, 0, 0, loc), time.Date(2023, 11, 5, 7, 0, 0, 0, loc)},
+	} {
+		next := schedule.Next(tc.now)
+		if !next.Equal(tc.want) {
+			t.Errorf("Next(%v) = %v, want %v", tc.now, next, tc.want)
+		}
+		// IsDark switches at Next.
+		if schedule.IsDark(next.Add(-time.Second)) == schedule.IsDark(next) {
+			t.Errorf("IsDark does not switch at Next(%v) = %v", tc.now, next)
+		}
+	}
+}
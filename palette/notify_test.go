@@ -0,0 +1,100 @@
+// Copyright (c) 2023 https://github.com/gio-eui
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR a PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+//
+// SPDX-License-Identifier: MIT
+package palette
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/gio-eui/md3-palettes/scheme"
+)
+
+// TestSubscribe checks that subscribers are notified once per change of the active scheme, and
+// without holding the lock of the palette: the subscriber reads and subscribes to the palette,
+// which would deadlock otherwise.
+func TestSubscribe(t *testing.T) {
+	p := NewPaletteFromSeed(0xff6750a4, scheme.VariantTonalSpot, 0)
+	var changes []Change
+	var errs []error
+	unsubscribe := p.Subscribe(func(change Change) {
+		changes = append(changes, change)
+		snap := p.Snapshot()
+		if snap.Active != change.New || snap.IsDark != change.IsDark {
+			errs = append(errs, fmt.Errorf("notified of %+v, but the snapshot is %+v", change, snap))
+		}
+		if p.ActiveScheme() != change.New {
+			errs = append(errs, fmt.Errorf("notified of %+v, but the active scheme is %p", change, p.ActiveScheme()))
+		}
+		p.Subscribe(func(Change) {})()
+	})
+
+	for _, step := range []struct {
+		name    string
+		change  func()
+		changed bool
+	}{
+		{"SwitchMode(false) in light mode", func() { p.SwitchMode(false) }, false},
+		{"SwitchMode(true)", func() { p.SwitchMode(true) }, true},
+		{"SwitchMode(true) in dark mode", func() { p.SwitchMode(true) }, false},
+		{"SetContrastLevel", func() { p.SetContrastLevel(0.5) }, true},
+		{"SetMode(ModeLight)", func() { _ = p.SetMode(ModeLight) }, true},
+		{"UpdateMode in light mode", func() { _ = p.UpdateMode(time.Now()) }, false},
+		{"SetExtendedColor", func() { p.SetExtendedColor("warning", 0xfff9a825, false) }, true},
+	} {
+		old := p.ActiveScheme()
+		changes, errs = nil, nil
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			step.change()
+		}()
+		select {
+		case <-done:
+		case <-time.After(5 * time.Second):
+			t.Fatalf("%s: deadlock, the subscriber is called with the lock held", step.name)
+		}
+		for _, err := range errs {
+			t.Errorf("%s: %v", step.name, err)
+		}
+
+		want := 0
+		if step.changed {
+			want = 1
+		}
+		if len(changes) != want {
+			t.Errorf("%s: notified %d times, want %d", step.name, len(changes), want)
+			continue
+		}
+		if want == 1 && (changes[0].Old != old || changes[0].New != p.ActiveScheme()) {
+			t.Errorf("%s: notified of a change from %p to %p, want from %p to %p",
+				step.name, changes[0].Old, changes[0].New, old, p.ActiveScheme())
+		}
+	}
+
+	// Once unsubscribed, the subscriber is not notified anymore.
+	unsubscribe()
+	changes = nil
+	p.SwitchMode(true)
+	if len(changes) != 0 {
+		t.Errorf("notified %d times after unsubscribing", len(changes))
+	}
+}
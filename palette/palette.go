@@ -21,34 +21,135 @@
 
 package palette
 
-import "github.com/gio-eui/md3-palettes/scheme"
+import (
+	"image"
+	"sync"
+	"sync/atomic"
 
+	"github.com/gio-eui/md3-colors/palettes"
+	"github.com/gio-eui/md3-palettes/scheme"
+)
+
+// Palette holds a light and a dark scheme, and the active one.
+//
+// A palette is safe for concurrent use through its methods: ActiveScheme and Snapshot can be
+// called while another goroutine switches the mode or regenerates the schemes. The fields must
+// not be changed directly once the palette is shared. The schemes of a palette are never changed
+// once set, as its methods replace them by new ones.
 type Palette struct {
-	Light  *scheme.Scheme
-	Dark   *scheme.Scheme
-	Active *scheme.Scheme
+	// Light and Dark are the schemes of the palette, and Active is the one in use.
+	//
+	// Deprecated: the fields are not safe to read while another goroutine changes the palette:
+	// use Snapshot or ActiveScheme instead. They are only set to build a palette literal.
+	Light, Dark, Active *scheme.Scheme
 
+	// IsDark reports whether Active is the dark scheme.
+	//
+	// Deprecated: the field is not safe to read while another goroutine changes the palette:
+	// use Snapshot instead. It is only set to build a palette literal.
 	IsDark bool
+
+	// ContrastLevel is the contrast level of the schemes, from -1 (reduced) to 1 (high).
+	//
+	// Deprecated: the field is not safe to read while another goroutine changes the palette:
+	// use Snapshot instead. It is only set to build a palette literal.
+	ContrastLevel float64
+
+	// Mode is how the active scheme is chosen, applied by SetMode, UpdateMode and WatchMode.
+	//
+	// Deprecated: the field is not safe to read while another goroutine changes the palette:
+	// use Snapshot instead. It is only set to build a palette literal.
+	Mode Mode
+	// SystemModeProvider reads the system color scheme in system mode.
+	// When nil, DefaultSystemModeProvider is used.
+	SystemModeProvider SystemModeProvider
+	// Schedule holds the switch times in scheduled mode. When zero, DefaultSchedule is used.
+	Schedule Schedule
+
+	mu          sync.Mutex
+	active      atomic.Pointer[scheme.Scheme]
+	subscribers map[int]func(Change)
+	nextID      int
+	modeChange  chan struct{}
+}
+
+// Snapshot is a consistent copy of the state of a palette.
+type Snapshot struct {
+	Light         *scheme.Scheme
+	Dark          *scheme.Scheme
+	Active        *scheme.Scheme
+	IsDark        bool
+	ContrastLevel float64
+	Mode          Mode
 }
 
 // NewPaletteFromInt creates a new palette from a primary color.
 // Each color is formatted as an int representing an argb color.
 // For example, 0xff000000 is black, 0xffffffff is white, 0xffff0000 is red, etc.
-func NewPaletteFromInt(primary int, secondary int, tertiary int, neutral int, neutralVariant int) *Palette {
+// The contrast level goes from -1 (reduced) to 1 (high), 0 being the standard contrast.
+func NewPaletteFromInt(primary int, secondary int, tertiary int, neutral int, neutralVariant int, contrastLevel float64) *Palette {
 	// Light scheme
-	light := scheme.Light(primary, secondary, tertiary, neutral, neutralVariant).WithErrorTonalPalette(scheme.ErrorTonalPalette, false)
+	light := scheme.Light(primary, secondary, tertiary, neutral, neutralVariant).WithErrorTonalPalette(scheme.ErrorTonalPalette, false).WithContrastLevel(contrastLevel)
 	// Dark scheme
-	dark := scheme.Dark(primary, secondary, tertiary, neutral, neutralVariant).WithErrorTonalPalette(scheme.ErrorTonalPalette, true)
+	dark := scheme.Dark(primary, secondary, tertiary, neutral, neutralVariant).WithErrorTonalPalette(scheme.ErrorTonalPalette, true).WithContrastLevel(contrastLevel)
 	// Active scheme is by default the light scheme
 	active := light
 	isDark := false
 
 	// Create the palette
 	return &Palette{
-		Light:  light,
-		Dark:   dark,
-		Active: active,
-		IsDark: isDark,
+		Light:         light,
+		Dark:          dark,
+		Active:        active,
+		IsDark:        isDark,
+		ContrastLevel: light.ContrastLevel(),
+	}
+}
+
+// NewPaletteFromSeed creates a new palette whose schemes are derived from a single seed color.
+// The seed is formatted as an int representing an argb color.
+// The contrast level goes from -1 (reduced) to 1 (high), 0 being the standard contrast.
+func NewPaletteFromSeed(seed int, variant scheme.Variant, contrastLevel float64) *Palette {
+	// Light scheme
+	light := scheme.FromSeed(seed, variant, false).WithContrastLevel(contrastLevel)
+	// Dark scheme
+	dark := scheme.FromSeed(seed, variant, true).WithContrastLevel(contrastLevel)
+	// Active scheme is by default the light scheme
+	active := light
+	isDark := false
+
+	// Create the palette
+	return &Palette{
+		Light:         light,
+		Dark:          dark,
+		Active:        active,
+		IsDark:        isDark,
+		ContrastLevel: light.ContrastLevel(),
+	}
+}
+
+// NewPaletteFromImage creates a new palette from the best seed color of an image.
+// It also returns up to four seed colors extracted from the image, ranked from the best
+// to the worst, so that the seed can be changed with NewPaletteFromSeed.
+// The contrast level goes from -1 (reduced) to 1 (high), 0 being the standard contrast.
+func NewPaletteFromImage(img image.Image, variant scheme.Variant, contrastLevel float64) (*Palette, []int) {
+	seeds := scheme.SeedsFromImage(img, 4)
+	return NewPaletteFromSeed(seeds[0], variant, contrastLevel), seeds
+}
+
+// NewPaletteFromSchemes creates a new palette from existing light and dark schemes.
+func NewPaletteFromSchemes(light *scheme.Scheme, dark *scheme.Scheme) *Palette {
+	// Active scheme is by default the light scheme
+	active := light
+	isDark := false
+
+	// Create the palette
+	return &Palette{
+		Light:         light,
+		Dark:          dark,
+		Active:        active,
+		IsDark:        isDark,
+		ContrastLevel: light.ContrastLevel(),
 	}
 }
 
@@ -83,12 +184,118 @@ func NewDefaultPalette() *Palette {
 	}
 }
 
-// SwitchMode changes the active scheme between light and dark.
+// SwitchMode changes the active scheme between light and dark, and sets the mode accordingly.
 func (p *Palette) SwitchMode(isDark bool) {
-	if isDark {
+	p.switchMode(isDark)
+}
+
+// switchMode switches the mode like SwitchMode, and returns the change of the active scheme.
+func (p *Palette) switchMode(isDark bool) Change {
+	return p.update(func() {
+		if isDark {
+			p.Mode = ModeDark
+		} else {
+			p.Mode = ModeLight
+		}
+		p.modeChanged()
+		p.IsDark = isDark
+	})
+}
+
+// ActiveScheme returns the active scheme. It is safe to call while another goroutine changes
+// the palette.
+func (p *Palette) ActiveScheme() *scheme.Scheme {
+	if active := p.active.Load(); active != nil {
+		return active
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.active.Store(p.Active)
+	return p.Active
+}
+
+// Snapshot returns a consistent copy of the state of the palette. It is safe to call while
+// another goroutine changes the palette.
+func (p *Palette) Snapshot() Snapshot {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return Snapshot{
+		Light:         p.Light,
+		Dark:          p.Dark,
+		Active:        p.Active,
+		IsDark:        p.IsDark,
+		ContrastLevel: p.ContrastLevel,
+		Mode:          p.Mode,
+	}
+}
+
+// update calls f to change the palette while holding its lock, then sets the active scheme and
+// notifies the subscribers, outside of the lock, when it changed. The change is returned.
+func (p *Palette) update(f func()) Change {
+	p.mu.Lock()
+	old := p.Active
+	f()
+	if p.IsDark {
 		p.Active = p.Dark
 	} else {
 		p.Active = p.Light
 	}
-	p.IsDark = isDark
+	p.active.Store(p.Active)
+	change := Change{Old: old, New: p.Active, IsDark: p.IsDark}
+	var subscribers []func(Change)
+	if change.New != change.Old {
+		subscribers = make([]func(Change), 0, len(p.subscribers))
+		for _, f := range p.subscribers {
+			subscribers = append(subscribers, f)
+		}
+	}
+	p.mu.Unlock()
+
+	for _, f := range subscribers {
+		f(change)
+	}
+	return change
+}
+
+// SetContrastLevel changes the contrast level of both the light and dark schemes,
+// from -1 (reduced) to 1 (high), 0 being the standard contrast.
+func (p *Palette) SetContrastLevel(contrastLevel float64) {
+	p.update(func() {
+		p.Light = p.Light.WithContrastLevel(contrastLevel)
+		p.Dark = p.Dark.WithContrastLevel(contrastLevel)
+		p.ContrastLevel = p.Light.ContrastLevel()
+	})
+}
+
+// SetHarmonization sets whether the custom and error tonal palettes of both the light and
+// dark schemes are harmonized with their primary tonal palette.
+func (p *Palette) SetHarmonization(harmonizeCustom bool, harmonizeError bool) {
+	p.update(func() {
+		p.Light = p.Light.WithHarmonization(harmonizeCustom, harmonizeError)
+		p.Dark = p.Dark.WithHarmonization(harmonizeCustom, harmonizeError)
+	})
+}
+
+// SetExtendedColor adds the extended color with the given name to both the light and dark schemes,
+// or replaces it. The color is formatted as an int representing an argb color.
+// When harmonize is true, the color is harmonized with the primary tonal palette of the schemes.
+func (p *Palette) SetExtendedColor(name string, extended int, harmonize bool) {
+	tp := palettes.NewTonalPaletteFromInt(extended)
+	p.update(func() {
+		p.Light = p.Light.WithExtendedColor(name, tp, harmonize)
+		p.Dark = p.Dark.WithExtendedColor(name, tp, harmonize)
+	})
+}
+
+// RemoveExtendedColor removes the extended color with the given name from both the light and dark schemes.
+func (p *Palette) RemoveExtendedColor(name string) {
+	p.update(func() {
+		p.Light = p.Light.WithoutExtendedColor(name)
+		p.Dark = p.Dark.WithoutExtendedColor(name)
+	})
+}
+
+// ExtendedColor returns the extended color of the active scheme with the given name.
+func (p *Palette) ExtendedColor(name string) (scheme.ExtendedColor, bool) {
+	return p.ActiveScheme().ExtendedColor(name)
 }
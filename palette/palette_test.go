@@ -0,0 +1,152 @@
+// Copyright (c) 2023 https://github.com/gio-eui
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR a PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+//
+// SPDX-License-Identifier: MIT
+
+package palette
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/gio-eui/md3-palettes/scheme"
+)
+
+// TestPaletteConcurrency changes a palette while other goroutines read it.
+// Run it with the race detector: go test -race.
+func TestPaletteConcurrency(t *testing.T) {
+	p := NewPaletteFromSeed(0xff6750a4, scheme.VariantTonalSpot, 0)
+	const iterations = 50
+
+	var writers, readers sync.WaitGroup
+	done := make(chan struct{})
+	errs := make(chan error, 16)
+	report := func(err error) {
+		select {
+		case errs <- err:
+		default:
+		}
+	}
+
+	writers.Add(3)
+	go func() {
+		defer writers.Done()
+		for i := 0; i < iterations; i++ {
+			p.SwitchMode(i%2 == 0)
+		}
+	}()
+	go func() {
+		defer writers.Done()
+		for i := 0; i < iterations; i++ {
+			p.SetContrastLevel(float64(i%3) / 2)
+		}
+	}()
+	go func() {
+		defer writers.Done()
+		for i := 0; i < iterations; i++ {
+			p.SetExtendedColor("warning", 0xffffb300+i, i%2 == 0)
+		}
+	}()
+
+	readers.Add(3)
+	go func() {
+		defer readers.Done()
+		for {
+			select {
+			case <-done:
+				return
+			default:
+			}
+			active := p.ActiveScheme()
+			if active == nil {
+				report(fmt.Errorf("no active scheme"))
+			} else if _, ok := active.Color(scheme.RolePrimary); !ok {
+				report(fmt.Errorf("active scheme has no primary color"))
+			}
+		}
+	}()
+	go func() {
+		defer readers.Done()
+		for {
+			select {
+			case <-done:
+				return
+			default:
+			}
+			snap := p.Snapshot()
+			want := snap.Light
+			if snap.IsDark {
+				want = snap.Dark
+			}
+			if snap.Active != want {
+				report(fmt.Errorf("snapshot: dark=%t, but the other scheme is active", snap.IsDark))
+			}
+			if snap.Light.ContrastLevel() != snap.ContrastLevel || snap.Dark.ContrastLevel() != snap.ContrastLevel {
+				report(fmt.Errorf("snapshot: contrast level %v, schemes %v and %v",
+					snap.ContrastLevel, snap.Light.ContrastLevel(), snap.Dark.ContrastLevel()))
+			}
+		}
+	}()
+	go func() {
+		defer readers.Done()
+		for {
+			select {
+			case <-done:
+				return
+			default:
+			}
+			unsubscribe := p.Subscribe(func(change Change) {
+				if change.New == nil || change.New == change.Old {
+					report(fmt.Errorf("notified of a change from %p to %p", change.Old, change.New))
+				}
+			})
+			unsubscribe()
+		}
+	}()
+
+	// A subscriber registered for the whole test is notified of the changes.
+	var changes atomic.Int64
+	unsubscribe := p.Subscribe(func(Change) { changes.Add(1) })
+	defer unsubscribe()
+
+	writers.Wait()
+	close(done)
+	readers.Wait()
+	close(errs)
+	for err := range errs {
+		t.Error(err)
+	}
+	if changes.Load() == 0 {
+		t.Error("no change notified")
+	}
+
+	snap := p.Snapshot()
+	if snap.IsDark || snap.Mode != ModeLight || snap.Active != snap.Light {
+		t.Errorf("final state: dark=%t, mode=%s", snap.IsDark, snap.Mode)
+	}
+	if snap.ContrastLevel != 0.5 {
+		t.Errorf("final contrast level %v, want 0.5", snap.ContrastLevel)
+	}
+	e, ok := snap.Active.ExtendedColor("warning")
+	if !ok || e.Harmonized() {
+		t.Errorf("final extended color %+v, %t", e, ok)
+	}
+}
@@ -0,0 +1,170 @@
+// Copyright (c) 2023 https://github.com/gio-eui
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR a PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+//
+// SPDX-License-Identifier: MIT
+
+package palette
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/gio-eui/md3-colors/palettes"
+	"github.com/gio-eui/md3-palettes/scheme"
+)
+
+// themeBuilderJSON is the JSON file exported by Material Theme Builder.
+// The tonal palettes it lists are not read: they are regenerated from the core colors.
+type themeBuilderJSON struct {
+	Seed           string            `json:"seed"`
+	CoreColors     map[string]string `json:"coreColors"`
+	ExtendedColors []struct {
+		Name       string `json:"name"`
+		Color      string `json:"color"`
+		Harmonized bool   `json:"harmonized"`
+	} `json:"extendedColors"`
+	Schemes map[string]map[string]string `json:"schemes"`
+}
+
+// themeBuilderSchemes are the schemes of a Material Theme Builder export.
+// Only the standard contrast ones, light and dark, are loaded.
+var themeBuilderSchemes = map[string]struct{}{
+	"light":                 {},
+	"dark":                  {},
+	"light-medium-contrast": {},
+	"dark-medium-contrast":  {},
+	"light-high-contrast":   {},
+	"dark-high-contrast":    {},
+}
+
+// FromThemeBuilderJSON creates a new palette from the JSON file exported by Material Theme Builder.
+// The colors of the light and dark schemes are exactly the ones of the export, and the tonal
+// palettes are built from its core colors. Each extended color becomes a named extended color of
+// the schemes, and a single extended color also becomes the custom color.
+// Unknown and missing roles are reported as errors.
+func FromThemeBuilderJSON(r io.Reader) (*Palette, error) {
+	var v themeBuilderJSON
+	if err := json.NewDecoder(r).Decode(&v); err != nil {
+		return nil, err
+	}
+	for name := range v.Schemes {
+		if _, ok := themeBuilderSchemes[name]; !ok {
+			return nil, fmt.Errorf("palette: unknown scheme %q", name)
+		}
+	}
+	light, err := v.buildScheme("light", false)
+	if err != nil {
+		return nil, err
+	}
+	dark, err := v.buildScheme("dark", true)
+	if err != nil {
+		return nil, err
+	}
+	return NewPaletteFromSchemes(light, dark), nil
+}
+
+// buildScheme builds the scheme with the given name from the export.
+func (v *themeBuilderJSON) buildScheme(name string, isDark bool) (*scheme.Scheme, error) {
+	colors, ok := v.Schemes[name]
+	if !ok {
+		return nil, fmt.Errorf("palette: missing %s scheme", name)
+	}
+
+	// Start from the seed so that the palettes missing from the core colors are derived from it.
+	seed := v.Seed
+	if seed == "" {
+		seed = v.CoreColors["primary"]
+	}
+	if seed == "" {
+		return nil, errors.New("palette: missing seed color")
+	}
+	seedColor, err := scheme.ParseHex(seed)
+	if err != nil {
+		return nil, err
+	}
+	s := scheme.FromSeed(scheme.Argb(seedColor), scheme.VariantTonalSpot, isDark)
+
+	for key, hex := range v.CoreColors {
+		c, err := scheme.ParseHex(hex)
+		if err != nil {
+			return nil, err
+		}
+		tp := palettes.NewTonalPaletteFromInt(scheme.Argb(c))
+		switch key {
+		case "primary":
+			s = s.WithPrimaryTonalPalette(tp, isDark)
+		case "secondary":
+			s = s.WithSecondaryTonalPalette(tp, isDark)
+		case "tertiary":
+			s = s.WithTertiaryTonalPalette(tp, isDark)
+		case "error":
+			s = s.WithErrorTonalPalette(tp, isDark)
+		case "neutral":
+			s = s.WithNeutralTonalPalette(tp, isDark)
+		case "neutralVariant":
+			s = s.WithNeutralVariantTonalPalette(tp, isDark)
+		default:
+			return nil, fmt.Errorf("palette: unknown core color %q", key)
+		}
+	}
+
+	for _, extended := range v.ExtendedColors {
+		c, err := scheme.ParseHex(extended.Color)
+		if err != nil {
+			return nil, err
+		}
+		tp := palettes.NewTonalPaletteFromInt(scheme.Argb(c))
+		s = s.WithExtendedColor(extended.Name, tp, extended.Harmonized)
+		if len(v.ExtendedColors) == 1 {
+			s = s.WithCustomTonalPalette(tp, isDark)
+		}
+	}
+
+	if err := setRoles(s, colors, name); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// setRoles sets the colors of a scheme from hex colors keyed by role name.
+// Every role but the custom ones must be listed.
+func setRoles(s *scheme.Scheme, colors map[string]string, name string) error {
+	for role, hex := range colors {
+		c, err := scheme.ParseHex(hex)
+		if err != nil {
+			return err
+		}
+		if !s.SetColor(scheme.Role(role), c) {
+			return fmt.Errorf("palette: unknown role %q in %s scheme", role, name)
+		}
+	}
+	var missing []string
+	for _, role := range scheme.Roles() {
+		if _, ok := colors[string(role)]; !ok && !role.IsCustom() {
+			missing = append(missing, string(role))
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("palette: missing roles in %s scheme: %s", name, strings.Join(missing, ", "))
+	}
+	return nil
+}
@@ -0,0 +1,146 @@
+// Copyright (c) 2023 https://github.com/gio-eui
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR a PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+//
+// SPDX-License-Identifier: MIT
+
+package palette
+
+import (
+	"encoding/json"
+	"image/color"
+	"strings"
+	"testing"
+
+	"github.com/gio-eui/md3-palettes/scheme"
+)
+
+// themeBuilderExport returns a Material Theme Builder export of the schemes of a palette.
+func themeBuilderExport(p *Palette) map[string]interface{} {
+	snap := p.Snapshot()
+	roles := func(s *scheme.Scheme) map[string]string {
+		colors := make(map[string]string)
+		for _, role := range scheme.Roles() {
+			if !role.IsCustom() {
+				c, _ := s.Color(role)
+				colors[string(role)] = scheme.Hex(c)
+			}
+		}
+		return colors
+	}
+	return map[string]interface{}{
+		"seed": "#6750A4",
+		"coreColors": map[string]string{
+			"primary": "#6750A4",
+		},
+		"extendedColors": []map[string]interface{}{
+			{"name": "warning", "color": "#FFB300", "harmonized": true},
+		},
+		"schemes": map[string]interface{}{
+			"light": roles(snap.Light),
+			"dark":  roles(snap.Dark),
+		},
+	}
+}
+
+func TestFromThemeBuilderJSON(t *testing.T) {
+	src := NewPaletteFromSeed(0xff6750a4, scheme.VariantTonalSpot, 0)
+	want := src.Snapshot()
+	p, err := FromThemeBuilderJSON(strings.NewReader(encodeJSON(t, themeBuilderExport(src))))
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := p.Snapshot()
+	for _, role := range scheme.Roles() {
+		if role.IsCustom() {
+			continue
+		}
+		if g, w := mustColor(got.Light, role), mustColor(want.Light, role); g != w {
+			t.Errorf("light %s is %s, want %s", role, scheme.Hex(g), scheme.Hex(w))
+		}
+		if g, w := mustColor(got.Dark, role), mustColor(want.Dark, role); g != w {
+			t.Errorf("dark %s is %s, want %s", role, scheme.Hex(g), scheme.Hex(w))
+		}
+	}
+	if e, ok := got.Light.ExtendedColor("warning"); !ok || !e.Harmonized() {
+		t.Errorf("extended color not loaded: %+v", e)
+	}
+	if !got.Light.HasCustomColor() {
+		t.Error("the single extended color is not the custom color")
+	}
+}
+
+func TestFromThemeBuilderJSONErrors(t *testing.T) {
+	p := NewPaletteFromSeed(0xff6750a4, scheme.VariantTonalSpot, 0)
+	tests := []struct {
+		name   string
+		modify func(v map[string]interface{})
+		err    string
+	}{
+		{"unknown role", func(v map[string]interface{}) {
+			schemeColors(v, "light")["notARole"] = "#FFFFFF"
+		}, `unknown role "notARole" in light scheme`},
+		{"missing role", func(v map[string]interface{}) {
+			delete(schemeColors(v, "dark"), "primary")
+		}, "missing roles in dark scheme: primary"},
+		{"bad hex color", func(v map[string]interface{}) {
+			schemeColors(v, "light")["primary"] = "#6750A"
+		}, `invalid hex color "#6750A"`},
+		{"missing scheme", func(v map[string]interface{}) {
+			delete(v["schemes"].(map[string]interface{}), "dark")
+		}, "missing dark scheme"},
+		{"unknown scheme", func(v map[string]interface{}) {
+			v["schemes"].(map[string]interface{})["sepia"] = map[string]string{}
+		}, `unknown scheme "sepia"`},
+		{"missing seed", func(v map[string]interface{}) {
+			delete(v, "seed")
+			delete(v, "coreColors")
+		}, "missing seed color"},
+		{"unknown core color", func(v map[string]interface{}) {
+			v["coreColors"].(map[string]string)["quaternary"] = "#FFFFFF"
+		}, `unknown core color "quaternary"`},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			v := themeBuilderExport(p)
+			test.modify(v)
+			_, err := FromThemeBuilderJSON(strings.NewReader(encodeJSON(t, v)))
+			if err == nil || !strings.Contains(err.Error(), test.err) {
+				t.Errorf("got error %v, want an error containing %q", err, test.err)
+			}
+		})
+	}
+}
+
+func schemeColors(v map[string]interface{}, name string) map[string]string {
+	return v["schemes"].(map[string]interface{})[name].(map[string]string)
+}
+
+func mustColor(s *scheme.Scheme, role scheme.Role) (c color.NRGBA) {
+	c, _ = s.Color(role)
+	return c
+}
+
+func encodeJSON(t *testing.T, v interface{}) string {
+	t.Helper()
+	data, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
@@ -0,0 +1,246 @@
+// Copyright (c) 2023 https://github.com/gio-eui
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR a PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+//
+// SPDX-License-Identifier: MIT
+
+package palette
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/gio-eui/md3-colors/palettes"
+	"github.com/gio-eui/md3-palettes/scheme"
+)
+
+// designTokenTones are the tones of each tonal palette exported as reference tokens.
+var designTokenTones = []int{0, 5, 10, 15, 20, 25, 30, 35, 40, 50, 60, 70, 80, 90, 95, 98, 99, 100}
+
+// designTokensExtension is the key of the extension recording the palette settings.
+const designTokensExtension = "io.github.gio-eui.md3-palettes"
+
+// designToken is a W3C design token.
+type designToken struct {
+	Type  string `json:"$type,omitempty"`
+	Value string `json:"$value"`
+}
+
+// designTokensSettings records the settings of a palette in a design tokens file.
+type designTokensSettings struct {
+	IsDark        bool    `json:"isDark"`
+	ContrastLevel float64 `json:"contrastLevel"`
+}
+
+// WriteDesignTokens writes the palette in the W3C Design Tokens Community Group format.
+// Each scheme role is a color token of the sys.light and sys.dark groups, such as
+// sys.light.primary, and each tone of the tonal palettes is a color token of the
+// ref.palette group, such as ref.palette.primary40. The key color of each tonal palette
+// is recorded as well, such as ref.palette.primaryKeyColor, so that the palette can be
+// regenerated by FromDesignTokens.
+func (p *Palette) WriteDesignTokens(w io.Writer) error {
+	snap := p.Snapshot()
+	ref := make(map[string]designToken)
+	for name, tp := range snap.Light.TonalPalettes() {
+		if tp == nil {
+			continue
+		}
+		ref[name+"KeyColor"] = colorToken(tp.GetKeyColor().ToInt())
+		for _, tone := range designTokenTones {
+			ref[name+strconv.Itoa(tone)] = colorToken(tp.Tone(tone))
+		}
+	}
+
+	data, err := json.MarshalIndent(map[string]interface{}{
+		"sys": map[string]interface{}{
+			"light": schemeTokens(snap.Light),
+			"dark":  schemeTokens(snap.Dark),
+		},
+		"ref": map[string]interface{}{
+			"palette": ref,
+		},
+		"$extensions": map[string]interface{}{
+			designTokensExtension: designTokensSettings{
+				IsDark:        snap.IsDark,
+				ContrastLevel: snap.ContrastLevel,
+			},
+		},
+	}, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(append(data, '\n'))
+	return err
+}
+
+// FromDesignTokens reads a palette written in the W3C Design Tokens Community Group format
+// by WriteDesignTokens. The tonal palettes are regenerated from the key colors of the
+// ref.palette group, and the light and dark schemes are set exactly from the sys.light and
+// sys.dark groups. Unknown or missing roles are reported as errors.
+func FromDesignTokens(r io.Reader) (*Palette, error) {
+	var v struct {
+		Sys struct {
+			Light map[string]json.RawMessage `json:"light"`
+			Dark  map[string]json.RawMessage `json:"dark"`
+		} `json:"sys"`
+		Ref struct {
+			Palette map[string]json.RawMessage `json:"palette"`
+		} `json:"ref"`
+		Extensions map[string]json.RawMessage `json:"$extensions"`
+	}
+	if err := json.NewDecoder(r).Decode(&v); err != nil {
+		return nil, err
+	}
+	if v.Sys.Light == nil {
+		return nil, errors.New("palette: missing sys.light group")
+	}
+	if v.Sys.Dark == nil {
+		return nil, errors.New("palette: missing sys.dark group")
+	}
+
+	var settings designTokensSettings
+	if raw, ok := v.Extensions[designTokensExtension]; ok {
+		if err := json.Unmarshal(raw, &settings); err != nil {
+			return nil, err
+		}
+	}
+	ref, err := decodeColorTokens(v.Ref.Palette, "ref.palette")
+	if err != nil {
+		return nil, err
+	}
+	light, err := decodeColorTokens(v.Sys.Light, "sys.light")
+	if err != nil {
+		return nil, err
+	}
+	dark, err := decodeColorTokens(v.Sys.Dark, "sys.dark")
+	if err != nil {
+		return nil, err
+	}
+
+	// Light scheme
+	lightScheme, err := buildTokensScheme(ref, light, "light", false, settings.ContrastLevel)
+	if err != nil {
+		return nil, err
+	}
+	// Dark scheme
+	darkScheme, err := buildTokensScheme(ref, dark, "dark", true, settings.ContrastLevel)
+	if err != nil {
+		return nil, err
+	}
+
+	// Create the palette
+	p := NewPaletteFromSchemes(lightScheme, darkScheme)
+	p.SwitchMode(settings.IsDark)
+	return p, nil
+}
+
+// buildTokensScheme builds a scheme from the tonal palettes of the key colors of ref,
+// then sets every role from colors.
+func buildTokensScheme(ref map[string]string, colors map[string]string, name string, isDark bool, contrastLevel float64) (*scheme.Scheme, error) {
+	s := &scheme.Scheme{}
+	for token, hex := range ref {
+		paletteName, ok := strings.CutSuffix(token, "KeyColor")
+		if !ok {
+			continue
+		}
+		key, err := scheme.ParseHex(hex)
+		if err != nil {
+			return nil, err
+		}
+		s, err = s.WithTonalPalette(paletteName, palettes.NewTonalPaletteFromInt(scheme.Argb(key)), isDark)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if s.TonalPalettes()["error"] == nil {
+		s = s.WithErrorTonalPalette(scheme.ErrorTonalPalette, isDark)
+	}
+	s = s.WithContrastLevel(contrastLevel)
+
+	if err := setRoles(s, colors, name); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// schemeTokens returns the color tokens of the roles of a scheme.
+// The custom roles are left out when the scheme has no custom color.
+func schemeTokens(s *scheme.Scheme) map[string]designToken {
+	tokens := make(map[string]designToken)
+	for _, role := range scheme.Roles() {
+		if role.IsCustom() && !s.HasCustomColor() {
+			continue
+		}
+		c, _ := s.Color(role)
+		tokens[string(role)] = designToken{Type: "color", Value: scheme.Hex(c)}
+	}
+	return tokens
+}
+
+// colorToken returns the color token of an argb color.
+func colorToken(argb int) designToken {
+	return designToken{Type: "color", Value: scheme.Hex(scheme.NRGBA(argb))}
+}
+
+// decodeColorTokens decodes the color tokens of a group into hex colors keyed by token name.
+// Group properties such as $type and $description are skipped, and a $type set on the
+// group applies to its tokens.
+func decodeColorTokens(group map[string]json.RawMessage, path string) (map[string]string, error) {
+	groupType := "color"
+	if raw, ok := group["$type"]; ok {
+		if err := json.Unmarshal(raw, &groupType); err != nil {
+			return nil, err
+		}
+	}
+	colors := make(map[string]string, len(group))
+	for name, raw := range group {
+		if strings.HasPrefix(name, "$") {
+			continue
+		}
+		var token struct {
+			Type  string          `json:"$type"`
+			Value json.RawMessage `json:"$value"`
+		}
+		if err := json.Unmarshal(raw, &token); err != nil {
+			return nil, err
+		}
+		if token.Type == "" {
+			token.Type = groupType
+		}
+		if token.Type != "color" {
+			return nil, fmt.Errorf("palette: token %s.%s has type %q, not color", path, name, token.Type)
+		}
+		// The value is either a hex string, or an object with a hex member.
+		var hex string
+		if err := json.Unmarshal(token.Value, &hex); err != nil {
+			var value struct {
+				Hex string `json:"hex"`
+			}
+			if err := json.Unmarshal(token.Value, &value); err != nil || value.Hex == "" {
+				return nil, fmt.Errorf("palette: invalid color value for token %s.%s", path, name)
+			}
+			hex = value.Hex
+		}
+		colors[name] = hex
+	}
+	return colors, nil
+}
@@ -0,0 +1,135 @@
+// Copyright (c) 2023 https://github.com/gio-eui
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR a PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+//
+// SPDX-License-Identifier: MIT
+
+package palette
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestDesignTokensRoundTrip(t *testing.T) {
+	var want bytes.Buffer
+	if err := testPalette().WriteDesignTokens(&want); err != nil {
+		t.Fatal(err)
+	}
+	p, err := FromDesignTokens(bytes.NewReader(want.Bytes()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got bytes.Buffer
+	if err := p.WriteDesignTokens(&got); err != nil {
+		t.Fatal(err)
+	}
+	if got.String() != want.String() {
+		t.Errorf("tokens changed after a round trip:\n%s\n%s", want.String(), got.String())
+	}
+	if !p.Snapshot().IsDark {
+		t.Error("the dark scheme is not active after reading the tokens of a dark palette")
+	}
+}
+
+func TestFromDesignTokensValueForms(t *testing.T) {
+	v := designTokens(t)
+	light := tokenGroup(v, "sys", "light")
+	// A type set on the group applies to its tokens.
+	light["$type"] = "color"
+	light["$description"] = "Light scheme"
+	for name, token := range light {
+		if token, ok := token.(map[string]interface{}); ok {
+			delete(token, "$type")
+			light[name] = token
+		}
+	}
+	// A value can be an object with a hex member.
+	primary := light["primary"].(map[string]interface{})
+	primary["$value"] = map[string]interface{}{"colorSpace": "srgb", "hex": primary["$value"]}
+
+	p, err := FromDesignTokens(strings.NewReader(encodeJSON(t, v)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := testPalette().Snapshot().Light.Primary
+	if got := p.Snapshot().Light.Primary; got != want {
+		t.Errorf("light primary is %v, want %v", got, want)
+	}
+}
+
+func TestFromDesignTokensErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(v map[string]interface{})
+		err    string
+	}{
+		{"unknown role", func(v map[string]interface{}) {
+			tokenGroup(v, "sys", "light")["notARole"] = map[string]interface{}{"$type": "color", "$value": "#FFFFFF"}
+		}, `unknown role "notARole" in light scheme`},
+		{"missing role", func(v map[string]interface{}) {
+			delete(tokenGroup(v, "sys", "dark"), "primary")
+		}, "missing roles in dark scheme: primary"},
+		{"bad hex color", func(v map[string]interface{}) {
+			tokenGroup(v, "sys", "light")["primary"] = map[string]interface{}{"$type": "color", "$value": "#GGGGGG"}
+		}, `invalid hex color "#GGGGGG"`},
+		{"bad key color", func(v map[string]interface{}) {
+			tokenGroup(v, "ref", "palette")["primaryKeyColor"] = map[string]interface{}{"$type": "color", "$value": "#123"}
+		}, `invalid hex color "#123"`},
+		{"missing scheme", func(v map[string]interface{}) {
+			delete(tokenGroup(v, "sys"), "dark")
+		}, "missing sys.dark group"},
+		{"not a color", func(v map[string]interface{}) {
+			tokenGroup(v, "sys", "light")["elevation"] = map[string]interface{}{"$type": "dimension", "$value": "1px"}
+		}, "not color"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			v := designTokens(t)
+			test.modify(v)
+			_, err := FromDesignTokens(strings.NewReader(encodeJSON(t, v)))
+			if err == nil || !strings.Contains(err.Error(), test.err) {
+				t.Errorf("got error %v, want an error containing %q", err, test.err)
+			}
+		})
+	}
+}
+
+// designTokens returns the design tokens of the test palette, decoded as generic JSON.
+func designTokens(t *testing.T) map[string]interface{} {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := testPalette().WriteDesignTokens(&buf); err != nil {
+		t.Fatal(err)
+	}
+	var v map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &v); err != nil {
+		t.Fatal(err)
+	}
+	return v
+}
+
+// tokenGroup returns the group at the given path of decoded design tokens.
+func tokenGroup(v map[string]interface{}, path ...string) map[string]interface{} {
+	for _, name := range path {
+		v = v[name].(map[string]interface{})
+	}
+	return v
+}
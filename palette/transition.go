@@ -0,0 +1,122 @@
+// Copyright (c) 2023 https://github.com/gio-eui
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR a PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+//
+// SPDX-License-Identifier: MIT
+
+package palette
+
+import (
+	"math"
+	"time"
+
+	"github.com/gio-eui/md3-palettes/scheme"
+)
+
+// Easing maps the progress of a transition, from 0 to 1, to the amount of interpolation.
+type Easing func(progress float64) float64
+
+// EaseLinear interpolates at a constant speed.
+func EaseLinear(progress float64) float64 {
+	return progress
+}
+
+// MD3 easing curves.
+var (
+	EaseStandard             = CubicBezier(0.2, 0, 0, 1)
+	EaseStandardDecelerate   = CubicBezier(0, 0, 0, 1)
+	EaseStandardAccelerate   = CubicBezier(0.3, 0, 1, 1)
+	EaseEmphasizedDecelerate = CubicBezier(0.05, 0.7, 0.1, 1)
+	EaseEmphasizedAccelerate = CubicBezier(0.3, 0, 0.8, 0.15)
+)
+
+// DefaultTransitionDuration is a duration suited to the transition between light and dark schemes.
+const DefaultTransitionDuration = 300 * time.Millisecond
+
+// CubicBezier returns the easing of the CSS cubic-bezier(x1, y1, x2, y2) timing function.
+func CubicBezier(x1 float64, y1 float64, x2 float64, y2 float64) Easing {
+	bezier := func(p1 float64, p2 float64, t float64) float64 {
+		u := 1 - t
+		return 3*u*u*t*p1 + 3*u*t*t*p2 + t*t*t
+	}
+	return func(progress float64) float64 {
+		if progress <= 0 || progress >= 1 {
+			return math.Max(0, math.Min(1, progress))
+		}
+		// Find the parameter whose x is the progress, by bisection as x is monotonic
+		low, high := 0.0, 1.0
+		t := progress
+		for i := 0; i < 32; i++ {
+			x := bezier(x1, x2, t)
+			if math.Abs(x-progress) < 1e-6 {
+				break
+			}
+			if x < progress {
+				low = t
+			} else {
+				high = t
+			}
+			t = (low + high) / 2
+		}
+		return bezier(y1, y2, t)
+	}
+}
+
+// Transition interpolates between two schemes over a duration, such as when the active scheme
+// of a palette changes.
+type Transition struct {
+	From     *scheme.Scheme
+	To       *scheme.Scheme
+	Duration time.Duration
+	Easing   Easing
+}
+
+// NewTransition creates a transition between two schemes. When easing is nil, EaseStandard is used.
+func NewTransition(from *scheme.Scheme, to *scheme.Scheme, duration time.Duration, easing Easing) *Transition {
+	if easing == nil {
+		easing = EaseStandard
+	}
+	return &Transition{
+		From:     from,
+		To:       to,
+		Duration: duration,
+		Easing:   easing,
+	}
+}
+
+// At returns the intermediate scheme after the elapsed time since the start of the transition.
+func (t *Transition) At(elapsed time.Duration) *scheme.Scheme {
+	if t.Done(elapsed) {
+		return t.To
+	}
+	progress := float64(elapsed) / float64(t.Duration)
+	return scheme.Lerp(t.From, t.To, t.Easing(math.Max(0, progress)))
+}
+
+// Done reports whether the transition is over after the elapsed time.
+func (t *Transition) Done(elapsed time.Duration) bool {
+	return elapsed >= t.Duration || t.From == nil || t.From == t.To
+}
+
+// SwitchModeWithTransition changes the active scheme between light and dark like SwitchMode, and
+// returns the transition from the previous active scheme to the new one. Both schemes are read
+// under the lock of the switch, so that a concurrent change cannot come in between.
+func (p *Palette) SwitchModeWithTransition(isDark bool, duration time.Duration, easing Easing) *Transition {
+	change := p.switchMode(isDark)
+	return NewTransition(change.Old, change.New, duration, easing)
+}
@@ -0,0 +1,135 @@
+// Copyright (c) 2023 https://github.com/gio-eui
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR a PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+//
+// SPDX-License-Identifier: MIT
+
+package palette
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gio-eui/md3-palettes/scheme"
+)
+
+func TestEasing(t *testing.T) {
+	for name, easing := range map[string]Easing{
+		"EaseLinear":               EaseLinear,
+		"EaseStandard":             EaseStandard,
+		"EaseStandardDecelerate":   EaseStandardDecelerate,
+		"EaseStandardAccelerate":   EaseStandardAccelerate,
+		"EaseEmphasizedDecelerate": EaseEmphasizedDecelerate,
+		"EaseEmphasizedAccelerate": EaseEmphasizedAccelerate,
+	} {
+		if got := easing(0); got != 0 {
+			t.Errorf("%s(0) = %v, want 0", name, got)
+		}
+		if got := easing(1); got != 1 {
+			t.Errorf("%s(1) = %v, want 1", name, got)
+		}
+		prev := 0.0
+		for i := 1; i <= 100; i++ {
+			got := easing(float64(i) / 100)
+			if got < prev-1e-9 {
+				t.Errorf("%s is not monotonic: %v at %v after %v", name, got, float64(i)/100, prev)
+				break
+			}
+			prev = got
+		}
+	}
+}
+
+func TestCubicBezier(t *testing.T) {
+	// The CSS ease timing function, cubic-bezier(0.25, 0.1, 0.25, 1), is at 0.8024 halfway.
+	ease := CubicBezier(0.25, 0.1, 0.25, 1)
+	if got := ease(0.5); got < 0.8023 || got > 0.8025 {
+		t.Errorf("ease(0.5) = %v, want 0.8024", got)
+	}
+	// Progress out of [0, 1] is clamped.
+	if got := ease(-0.5); got != 0 {
+		t.Errorf("ease(-0.5) = %v, want 0", got)
+	}
+	if got := ease(1.5); got != 1 {
+		t.Errorf("ease(1.5) = %v, want 1", got)
+	}
+}
+
+func TestTransition(t *testing.T) {
+	p := NewPaletteFromSeed(0xff6750a4, scheme.VariantTonalSpot, 0)
+	light := p.ActiveScheme()
+	tr := p.SwitchModeWithTransition(true, time.Second, nil)
+	dark := p.ActiveScheme()
+	if tr.From != light || tr.To != dark {
+		t.Fatal("transition is not from the previous active scheme to the new one")
+	}
+
+	if start := tr.At(0); start.Surface != light.Surface || start.OnSurface != light.OnSurface {
+		t.Errorf("start surface %s, want %s", scheme.Hex(start.Surface), scheme.Hex(light.Surface))
+	}
+	if tr.Done(tr.Duration - 1) {
+		t.Error("transition done before its duration")
+	}
+	if !tr.Done(tr.Duration) || tr.At(tr.Duration) != dark || tr.At(2*tr.Duration) != dark {
+		t.Error("transition does not end on the new active scheme")
+	}
+	mid := tr.At(tr.Duration / 2)
+	if mid.Surface == light.Surface || mid.Surface == dark.Surface {
+		t.Errorf("surface %s halfway", scheme.Hex(mid.Surface))
+	}
+
+	// Switching to the active mode has nothing to transition.
+	if tr := p.SwitchModeWithTransition(true, time.Second, nil); !tr.Done(0) || tr.At(0) != dark {
+		t.Error("transition without a change of the active scheme")
+	}
+}
+
+// TestSwitchModeWithTransitionConcurrency checks that every transition matches a change of the
+// active scheme, while another goroutine switches the mode.
+func TestSwitchModeWithTransitionConcurrency(t *testing.T) {
+	p := NewPaletteFromSeed(0xff6750a4, scheme.VariantTonalSpot, 0)
+	var changes, switches, transitions atomic.Int32
+	p.Subscribe(func(Change) { changes.Add(1) })
+
+	const iterations = 2000
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < iterations; i++ {
+			if change := p.switchMode(i%2 == 0); change.Old != change.New {
+				switches.Add(1)
+			}
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for i := 0; i < iterations; i++ {
+			if tr := p.SwitchModeWithTransition(i%3 == 0, time.Second, nil); tr.From != tr.To {
+				transitions.Add(1)
+			}
+		}
+	}()
+	wg.Wait()
+
+	if got, want := transitions.Load()+switches.Load(), changes.Load(); got != want {
+		t.Errorf("%d transitions and %d switches for %d changes of the active scheme", transitions.Load(), switches.Load(), want)
+	}
+}
@@ -0,0 +1,157 @@
+// Copyright (c) 2023 https://github.com/gio-eui
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR a PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+//
+// SPDX-License-Identifier: MIT
+
+package scheme
+
+import (
+	"image/color"
+	"math"
+)
+
+// APCAThresholds are the minimum absolute APCA lightness contrast (Lc) values required for
+// body text, large text and non-text elements such as icons and outlines.
+type APCAThresholds struct {
+	BodyText  float64
+	LargeText float64
+	NonText   float64
+}
+
+// DefaultAPCAThresholds are the APCA Bronze level thresholds: Lc 75 for body text,
+// Lc 60 for large text and Lc 45 for non-text elements.
+var DefaultAPCAThresholds = APCAThresholds{
+	BodyText:  75,
+	LargeText: 60,
+	NonText:   45,
+}
+
+// APCAContrast returns the APCA lightness contrast (Lc) of text on a background, following
+// the APCA-W3 0.0.98G-4g constants of the WCAG 3 draft. It ranges from about 106 for black
+// text on white to about -108 for white text on black, and is negative for light text on a
+// dark background. The alpha of the colors is ignored.
+func APCAContrast(text color.NRGBA, background color.NRGBA) float64 {
+	const (
+		normBG      = 0.56
+		normTXT     = 0.57
+		revTXT      = 0.62
+		revBG       = 0.65
+		scale       = 1.14
+		loOffset    = 0.027
+		loClip      = 0.1
+		deltaYMin   = 0.0005
+		blackThresh = 0.022
+		blackClamp  = 1.414
+	)
+
+	textY := apcaY(text)
+	backgroundY := apcaY(background)
+	if textY < blackThresh {
+		textY += math.Pow(blackThresh-textY, blackClamp)
+	}
+	if backgroundY < blackThresh {
+		backgroundY += math.Pow(blackThresh-backgroundY, blackClamp)
+	}
+	if math.Abs(backgroundY-textY) < deltaYMin {
+		return 0
+	}
+
+	if backgroundY > textY {
+		// Dark text on a light background
+		sapc := (math.Pow(backgroundY, normBG) - math.Pow(textY, normTXT)) * scale
+		if sapc < loClip {
+			return 0
+		}
+		return (sapc - loOffset) * 100
+	}
+	// Light text on a dark background
+	sapc := (math.Pow(backgroundY, revBG) - math.Pow(textY, revTXT)) * scale
+	if sapc > -loClip {
+		return 0
+	}
+	return (sapc + loOffset) * 100
+}
+
+// apcaY returns the screen luminance of a color as estimated by APCA.
+func apcaY(c color.NRGBA) float64 {
+	return 0.2126729*math.Pow(float64(c.R)/255, 2.4) +
+		0.7151522*math.Pow(float64(c.G)/255, 2.4) +
+		0.0721750*math.Pow(float64(c.B)/255, 2.4)
+}
+
+// APCAResult is the APCA lightness contrast of a role pair.
+type APCAResult struct {
+	ContrastPair
+	Lc float64
+
+	BodyText  bool
+	LargeText bool
+	NonText   bool
+}
+
+// Passed reports whether the pair reaches the threshold of its kind: the body text threshold
+// for text and the non-text threshold for non-text pairs.
+func (r APCAResult) Passed() bool {
+	if r.Kind == PairNonText {
+		return r.NonText
+	}
+	return r.BodyText
+}
+
+// APCAReport is the APCA lightness contrast of every role pair of a scheme.
+type APCAReport struct {
+	Thresholds APCAThresholds
+	Results    []APCAResult
+}
+
+// Failures returns the results that do not reach the threshold of their kind.
+func (r *APCAReport) Failures() []APCAResult {
+	var failures []APCAResult
+	for _, result := range r.Results {
+		if !result.Passed() {
+			failures = append(failures, result)
+		}
+	}
+	return failures
+}
+
+// Passed reports whether every role pair reaches the threshold of its kind.
+func (r *APCAReport) Passed() bool {
+	return len(r.Failures()) == 0
+}
+
+// AuditAPCA checks the APCA lightness contrast of every foreground and background role pair
+// of the scheme, as listed by ContrastPairs, against the thresholds.
+func (s *Scheme) AuditAPCA(thresholds APCAThresholds) *APCAReport {
+	pairs := s.ContrastPairs()
+	report := &APCAReport{Thresholds: thresholds, Results: make([]APCAResult, 0, len(pairs))}
+	for _, pair := range pairs {
+		fg, _ := s.Color(pair.Foreground)
+		bg, _ := s.Color(pair.Background)
+		lc := APCAContrast(fg, bg)
+		report.Results = append(report.Results, APCAResult{
+			ContrastPair: pair,
+			Lc:           lc,
+			BodyText:     math.Abs(lc) >= thresholds.BodyText,
+			LargeText:    math.Abs(lc) >= thresholds.LargeText,
+			NonText:      math.Abs(lc) >= thresholds.NonText,
+		})
+	}
+	return report
+}
@@ -0,0 +1,75 @@
+// Copyright (c) 2023 https://github.com/gio-eui
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR a PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+//
+// SPDX-License-Identifier: MIT
+
+package scheme
+
+import (
+	"math"
+	"testing"
+)
+
+func TestAPCAContrast(t *testing.T) {
+	// Reference values of APCA-W3 0.0.98G-4g: APCAcontrast(sRGBtoY(text), sRGBtoY(background)).
+	// Dark text on a light background is positive, light text on a dark background negative.
+	tests := []struct {
+		text       string
+		background string
+		want       float64
+	}{
+		{"#888888", "#FFFFFF", 63.056469930209424},
+		{"#FFFFFF", "#888888", -68.54146436644962},
+		{"#000000", "#AAAAAA", 58.146262578561334},
+		{"#AAAAAA", "#000000", -56.24113336839742},
+		{"#112233", "#DDEEFF", 91.66830811481631},
+		{"#DDEEFF", "#112233", -93.06770049484275},
+		{"#112233", "#444444", 8.32326136957393},
+		{"#444444", "#112233", -7.526878460278154},
+		{"#777777", "#777777", 0},
+	}
+	for _, tt := range tests {
+		text, _ := ParseHex(tt.text)
+		background, _ := ParseHex(tt.background)
+		if got := APCAContrast(text, background); math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("APCAContrast(%s, %s) = %v, want %v", tt.text, tt.background, got, tt.want)
+		}
+	}
+}
+
+func TestAuditAPCA(t *testing.T) {
+	s := FromSeed(0xff6750a4, VariantTonalSpot, false)
+	report := s.AuditAPCA(DefaultAPCAThresholds)
+	if len(report.Results) != len(s.ContrastPairs()) {
+		t.Fatalf("%d results for %d pairs", len(report.Results), len(s.ContrastPairs()))
+	}
+	for _, r := range report.Results {
+		fg, _ := s.Color(r.Foreground)
+		bg, _ := s.Color(r.Background)
+		if r.Lc != APCAContrast(fg, bg) {
+			t.Errorf("%s on %s: Lc %v, want %v", r.Foreground, r.Background, r.Lc, APCAContrast(fg, bg))
+		}
+		if want := math.Abs(r.Lc) >= DefaultAPCAThresholds.BodyText; r.Kind == PairText && r.Passed() != want {
+			t.Errorf("%s on %s: text pair with Lc %.1f passed=%t", r.Foreground, r.Background, r.Lc, r.Passed())
+		}
+		if want := math.Abs(r.Lc) >= DefaultAPCAThresholds.NonText; r.Kind == PairNonText && r.Passed() != want {
+			t.Errorf("%s on %s: non-text pair with Lc %.1f passed=%t", r.Foreground, r.Background, r.Lc, r.Passed())
+		}
+	}
+}
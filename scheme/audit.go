@@ -0,0 +1,223 @@
+// Copyright (c) 2023 https://github.com/gio-eui
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR a PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+//
+// SPDX-License-Identifier: MIT
+
+package scheme
+
+import (
+	"fmt"
+	"strings"
+)
+
+// WCAG 2 minimum contrast ratios, for normal text and for large text, which is at least
+// 18pt, or 14pt bold, and for non-text elements such as icons, outlines and the state of
+// components.
+const (
+	WCAGAA       = 4.5
+	WCAGAALarge  = 3.0
+	WCAGAAA      = 7.0
+	WCAGAAALarge = 4.5
+	WCAGNonText  = 3.0
+)
+
+// PairKind tells what the foreground of a contrast pair is used for.
+type PairKind int
+
+const (
+	PairText    PairKind = iota // text and icons
+	PairNonText                 // outlines and accents that do not carry text
+)
+
+// String returns the name of the kind.
+func (k PairKind) String() string {
+	switch k {
+	case PairText:
+		return "text"
+	case PairNonText:
+		return "non-text"
+	default:
+		return fmt.Sprintf("PairKind(%d)", int(k))
+	}
+}
+
+// MinRatio returns the WCAG 2 AA contrast ratio of the kind: 4.5:1 for text and 3:1 for
+// non-text elements.
+func (k PairKind) MinRatio() float64 {
+	if k == PairNonText {
+		return WCAGNonText
+	}
+	return WCAGAA
+}
+
+// ContrastPair is a foreground role displayed on a background role.
+type ContrastPair struct {
+	Foreground Role
+	Background Role
+	Kind       PairKind
+}
+
+// String returns the pair as "foreground on background", followed by "(non-text)" for
+// non-text pairs.
+func (p ContrastPair) String() string {
+	if p.Kind == PairNonText {
+		return fmt.Sprintf("%s on %s (non-text)", p.Foreground, p.Background)
+	}
+	return fmt.Sprintf("%s on %s", p.Foreground, p.Background)
+}
+
+// contrastPairs are the foreground and background role pairs of a scheme.
+var contrastPairs = []ContrastPair{
+	{RoleOnPrimary, RolePrimary, PairText},
+	{RoleOnPrimaryContainer, RolePrimaryContainer, PairText},
+	{RoleOnPrimaryFixed, RolePrimaryFixed, PairText},
+	{RoleOnPrimaryFixed, RolePrimaryFixedDim, PairText},
+	{RoleOnPrimaryFixedVariant, RolePrimaryFixed, PairText},
+	{RoleOnPrimaryFixedVariant, RolePrimaryFixedDim, PairText},
+	{RoleOnSecondary, RoleSecondary, PairText},
+	{RoleOnSecondaryContainer, RoleSecondaryContainer, PairText},
+	{RoleOnSecondaryFixed, RoleSecondaryFixed, PairText},
+	{RoleOnSecondaryFixed, RoleSecondaryFixedDim, PairText},
+	{RoleOnSecondaryFixedVariant, RoleSecondaryFixed, PairText},
+	{RoleOnSecondaryFixedVariant, RoleSecondaryFixedDim, PairText},
+	{RoleOnTertiary, RoleTertiary, PairText},
+	{RoleOnTertiaryContainer, RoleTertiaryContainer, PairText},
+	{RoleOnTertiaryFixed, RoleTertiaryFixed, PairText},
+	{RoleOnTertiaryFixed, RoleTertiaryFixedDim, PairText},
+	{RoleOnTertiaryFixedVariant, RoleTertiaryFixed, PairText},
+	{RoleOnTertiaryFixedVariant, RoleTertiaryFixedDim, PairText},
+	{RoleOnCustom, RoleCustom, PairText},
+	{RoleOnCustomContainer, RoleCustomContainer, PairText},
+	{RoleOnCustomFixed, RoleCustomFixed, PairText},
+	{RoleOnCustomFixed, RoleCustomFixedDim, PairText},
+	{RoleOnCustomFixedVariant, RoleCustomFixed, PairText},
+	{RoleOnCustomFixedVariant, RoleCustomFixedDim, PairText},
+	{RoleOnError, RoleError, PairText},
+	{RoleOnErrorContainer, RoleErrorContainer, PairText},
+	{RoleOnSurface, RoleSurface, PairText},
+	{RoleOnSurface, RoleSurfaceDim, PairText},
+	{RoleOnSurface, RoleSurfaceBright, PairText},
+	{RoleOnSurface, RoleSurfaceContainerLowest, PairText},
+	{RoleOnSurface, RoleSurfaceContainerLow, PairText},
+	{RoleOnSurface, RoleSurfaceContainer, PairText},
+	{RoleOnSurface, RoleSurfaceContainerHigh, PairText},
+	{RoleOnSurface, RoleSurfaceContainerHighest, PairText},
+	{RoleOnSurfaceVariant, RoleSurface, PairText},
+	{RoleOnSurfaceVariant, RoleSurfaceVariant, PairText},
+	{RoleOnSurfaceVariant, RoleSurfaceContainerHighest, PairText},
+	{RoleOnBackground, RoleBackground, PairText},
+	{RoleInverseOnSurface, RoleInverseSurface, PairText},
+	{RoleInversePrimary, RoleInverseSurface, PairNonText},
+	{RoleOutline, RoleSurface, PairNonText},
+}
+
+// ContrastPairs returns the foreground and background role pairs of the scheme, followed by
+// the pairs of its extended colors: onColor on color, and onColorContainer on colorContainer.
+// The custom pairs are left out when the scheme has no custom color.
+func (s *Scheme) ContrastPairs() []ContrastPair {
+	pairs := make([]ContrastPair, 0, len(contrastPairs)+2*len(s.extended))
+	for _, pair := range contrastPairs {
+		if pair.Foreground.IsCustom() && !s.HasCustomColor() {
+			continue
+		}
+		pairs = append(pairs, pair)
+	}
+	for _, e := range s.extended {
+		r := e.Roles()
+		pairs = append(pairs,
+			ContrastPair{r[1], r[0], PairText},
+			ContrastPair{r[3], r[2], PairText},
+		)
+	}
+	return pairs
+}
+
+// AuditResult is the WCAG 2 contrast of a role pair.
+type AuditResult struct {
+	ContrastPair
+	Ratio float64
+
+	AA       bool // normal text, 4.5:1
+	AALarge  bool // large text, 3:1
+	AAA      bool // normal text, 7:1
+	AAALarge bool // large text, 4.5:1
+}
+
+// Passed reports whether the pair reaches WCAG AA for its kind: 4.5:1 for text and 3:1 for
+// non-text pairs.
+func (r AuditResult) Passed() bool {
+	return r.Ratio >= r.Kind.MinRatio()
+}
+
+// AuditReport is the WCAG 2 contrast of every role pair of a scheme.
+type AuditReport struct {
+	Results []AuditResult
+}
+
+// Failures returns the results that do not reach AA for their kind.
+func (r *AuditReport) Failures() []AuditResult {
+	var failures []AuditResult
+	for _, result := range r.Results {
+		if !result.Passed() {
+			failures = append(failures, result)
+		}
+	}
+	return failures
+}
+
+// Passed reports whether every role pair reaches AA for its kind.
+func (r *AuditReport) Passed() bool {
+	return len(r.Failures()) == 0
+}
+
+// Audit checks the WCAG 2 contrast ratio of every foreground and background role pair of the
+// scheme, as listed by ContrastPairs.
+func (s *Scheme) Audit() *AuditReport {
+	pairs := s.ContrastPairs()
+	report := &AuditReport{Results: make([]AuditResult, 0, len(pairs))}
+	for _, pair := range pairs {
+		fg, _ := s.Color(pair.Foreground)
+		bg, _ := s.Color(pair.Background)
+		ratio := ContrastRatio(fg, bg)
+		report.Results = append(report.Results, AuditResult{
+			ContrastPair: pair,
+			Ratio:        ratio,
+			AA:           ratio >= WCAGAA,
+			AALarge:      ratio >= WCAGAALarge,
+			AAA:          ratio >= WCAGAAA,
+			AAALarge:     ratio >= WCAGAAALarge,
+		})
+	}
+	return report
+}
+
+// AuditStrict is like Audit, but also returns an error listing the role pairs that do not
+// reach AA for their kind: 4.5:1 for text and 3:1 for non-text pairs.
+func (s *Scheme) AuditStrict() (*AuditReport, error) {
+	report := s.Audit()
+	failures := report.Failures()
+	if len(failures) == 0 {
+		return report, nil
+	}
+	descriptions := make([]string, len(failures))
+	for i, failure := range failures {
+		descriptions[i] = fmt.Sprintf("%s (%.2f:1)", failure.ContrastPair, failure.Ratio)
+	}
+	return report, fmt.Errorf("scheme: %d role pairs below WCAG AA: %s", len(failures), strings.Join(descriptions, ", "))
+}
@@ -0,0 +1,147 @@
+// Copyright (c) 2023 https://github.com/gio-eui
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR a PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+//
+// SPDX-License-Identifier: MIT
+
+package scheme
+
+import (
+	"image/color"
+	"testing"
+
+	"github.com/gio-eui/md3-colors/palettes"
+)
+
+func TestAuditPairKinds(t *testing.T) {
+	gray := func(v uint8) color.NRGBA { return color.NRGBA{R: v, G: v, B: v, A: 0xff} }
+	white := gray(0xff)
+
+	s := FromSeed(0xff6750a4, VariantTonalSpot, false)
+	s.SetColor(RoleInverseSurface, white)
+	s.SetColor(RoleInverseOnSurface, gray(0))
+	s.SetColor(RolePrimary, white)
+
+	cases := []struct {
+		name   string
+		role   Role
+		c      color.NRGBA
+		failed bool
+	}{
+		// 3.54:1 reaches the 3:1 of non-text pairs, but not the 4.5:1 of text pairs.
+		{"non-text above 3:1", RoleInversePrimary, gray(0x88), false},
+		{"non-text below 3:1", RoleInversePrimary, gray(0xaa), true},
+		{"text below 4.5:1", RoleOnPrimary, gray(0x88), true},
+		{"text above 4.5:1", RoleOnPrimary, gray(0x70), false},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			s := s.Clone()
+			s.SetColor(RoleOnPrimary, gray(0))
+			s.SetColor(RoleInversePrimary, gray(0))
+			s.SetColor(tc.role, tc.c)
+
+			report, err := s.AuditStrict()
+			var failed bool
+			for _, failure := range report.Failures() {
+				if failure.Foreground == tc.role {
+					failed = true
+				}
+			}
+			if failed != tc.failed {
+				t.Errorf("%s failed = %v, want %v", tc.role, failed, tc.failed)
+			}
+			if tc.failed && err == nil {
+				t.Error("AuditStrict returned no error")
+			}
+		})
+	}
+}
+
+func TestContrastPairKinds(t *testing.T) {
+	for _, pair := range contrastPairs {
+		nonText := pair.Foreground == RoleInversePrimary || pair.Foreground == RoleOutline
+		if got := pair.Kind == PairNonText; got != nonText {
+			t.Errorf("%s: non-text = %v, want %v", pair, got, nonText)
+		}
+	}
+	if PairText.MinRatio() != WCAGAA || PairNonText.MinRatio() != WCAGNonText {
+		t.Errorf("MinRatio = %v, %v", PairText.MinRatio(), PairNonText.MinRatio())
+	}
+}
+
+func TestAuditExtendedColors(t *testing.T) {
+	s := FromSeed(0xff6750a4, VariantTonalSpot, false).
+		WithExtendedColor("warning", palettes.NewTonalPaletteFromInt(0xffffb300), true)
+	onColor, colorRole := ExtendedRole("warning", "onColor"), ExtendedRole("warning", "color")
+	pairs := make(map[ContrastPair]bool)
+	for _, pair := range s.ContrastPairs() {
+		pairs[pair] = true
+	}
+	for _, want := range []ContrastPair{
+		{onColor, colorRole, PairText},
+		{ExtendedRole("warning", "onColorContainer"), ExtendedRole("warning", "colorContainer"), PairText},
+	} {
+		if !pairs[want] {
+			t.Errorf("missing pair %s", want)
+		}
+	}
+
+	bg, _ := s.Color(colorRole)
+	if !s.SetColor(onColor, bg) {
+		t.Fatalf("SetColor(%s) failed", onColor)
+	}
+	var failed bool
+	for _, failure := range s.Audit().Failures() {
+		failed = failed || failure.Foreground == onColor
+	}
+	if !failed {
+		t.Errorf("%s on itself passed the audit", onColor)
+	}
+
+	repaired, _ := s.RepairContrast()
+	if e, _ := repaired.ExtendedColor("warning"); ContrastRatio(e.OnColor, e.Color) < WCAGAA {
+		t.Errorf("%s not repaired: %.2f:1", onColor, ContrastRatio(e.OnColor, e.Color))
+	}
+}
+
+func TestExtendedRole(t *testing.T) {
+	role := ExtendedRole("Success Green.v2", "onColorContainer")
+	name, r, ok := role.Extended()
+	if !ok || name != "Success Green.v2" || r != "onColorContainer" {
+		t.Errorf("Extended() = %q, %q, %v", name, r, ok)
+	}
+	if _, _, ok := RolePrimary.Extended(); ok {
+		t.Errorf("%s is an extended role", RolePrimary)
+	}
+
+	s := FromSeed(0xff6750a4, VariantTonalSpot, false)
+	if _, ok := s.Color(ExtendedRole("missing", "color")); ok {
+		t.Error("Color of a missing extended color succeeded")
+	}
+	s = s.WithExtendedColor("success", palettes.NewTonalPaletteFromInt(0xff2e7d32), false)
+	if _, ok := s.Color(ExtendedRole("success", "tone")); ok {
+		t.Error("Color of an unknown extended role succeeded")
+	}
+	e, _ := s.ExtendedColor("success")
+	for i, want := range []color.NRGBA{e.Color, e.OnColor, e.ColorContainer, e.OnColorContainer} {
+		if got, ok := s.Color(e.Roles()[i]); !ok || got != want {
+			t.Errorf("%s = %s, %v, want %s", e.Roles()[i], Hex(got), ok, Hex(want))
+		}
+	}
+}
@@ -0,0 +1,77 @@
+// Copyright (c) 2023 https://github.com/gio-eui
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR a PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+//
+// SPDX-License-Identifier: MIT
+
+package scheme
+
+import (
+	"math"
+
+	"github.com/gio-eui/md3-colors/palettes"
+)
+
+// Harmonize rotates the hue of a design color toward the hue of a source color, by half of
+// their hue difference and at most 15 degrees, keeping its chroma and tone, so that the
+// design color fits with the source color. Colors are formatted as ints representing argb colors.
+func Harmonize(design int, source int) int {
+	from := hctFromInt(design)
+	to := hctFromInt(source)
+	rotation := math.Min(differenceDegrees(from.hue, to.hue)*0.5, 15.0)
+	hue := sanitizeDegrees(from.hue + rotation*rotationDirection(from.hue, to.hue))
+	return hctToInt(hue, from.chroma, from.tone)
+}
+
+// WithHarmonization returns a copy of the scheme where the custom and error tonal palettes are
+// harmonized with its primary tonal palette or not, and the colors derived from them updated.
+// Harmonized palettes follow the primary tonal palette when it changes.
+func (s *Scheme) WithHarmonization(harmonizeCustom bool, harmonizeError bool) *Scheme {
+	c := s.Clone()
+	c.harmonizeCustom = harmonizeCustom
+	c.harmonizeError = harmonizeError
+	c.setCustomTonalPalette(c.customSource, c.isDark)
+	if c.errorSource != nil {
+		c.setErrorTonalPalette(c.errorSource, c.isDark)
+	}
+	c.updateRepairs()
+	return c
+}
+
+// harmonized returns the tonal palette of the key color of tp harmonized with the primary
+// tonal palette, or tp itself when harmonize is false or the scheme has no primary palette.
+func (s *Scheme) harmonized(tp *palettes.TonalPalette, harmonize bool) *palettes.TonalPalette {
+	if !harmonize || tp == nil || s.primaryTone == nil {
+		return tp
+	}
+	return palettes.NewTonalPaletteFromInt(Harmonize(tp.GetKeyColor().ToInt(), s.primaryTone.GetKeyColor().ToInt()))
+}
+
+// differenceDegrees returns the distance between two hues, in [0, 180].
+func differenceDegrees(a float64, b float64) float64 {
+	return 180.0 - math.Abs(math.Abs(a-b)-180.0)
+}
+
+// rotationDirection returns 1 if the shortest rotation from one hue to another is
+// increasing, -1 otherwise.
+func rotationDirection(from float64, to float64) float64 {
+	if sanitizeDegrees(to-from) <= 180.0 {
+		return 1.0
+	}
+	return -1.0
+}
@@ -0,0 +1,136 @@
+// Copyright (c) 2023 https://github.com/gio-eui
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR a PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+//
+// SPDX-License-Identifier: MIT
+
+package scheme
+
+import (
+	"math"
+	"testing"
+
+	"github.com/gio-eui/md3-colors/palettes"
+)
+
+func TestHarmonize(t *testing.T) {
+	// Reference values of Blend.harmonize in Material Color Utilities. They come from its exact
+	// HCT solver, while the bisection solver shared with md3-colors maps these colors on the edge
+	// of the sRGB gamut slightly differently, up to a CIELAB distance of 4.
+	const (
+		red    = 0xffff0000
+		green  = 0xff00ff00
+		blue   = 0xff0000ff
+		yellow = 0xffffff00
+	)
+	tests := []struct {
+		design int
+		source int
+		want   int
+	}{
+		{red, blue, 0xfffb0057},
+		{red, green, 0xffd85600},
+		{red, yellow, 0xffd85600},
+		{blue, green, 0xff0047a3},
+		{blue, red, 0xff5700dc},
+		{blue, yellow, 0xff0047a3},
+		{green, blue, 0xff00fc94},
+		{green, red, 0xffb1f000},
+		{green, yellow, 0xffb1f000},
+		{yellow, blue, 0xffebffba},
+		{yellow, green, 0xffebffba},
+		{yellow, red, 0xfffff6e3},
+	}
+	s := &Scheme{}
+	for _, tt := range tests {
+		got := Harmonize(tt.design, tt.source)
+		if distance := colorDistance(s.nrgba(got), s.nrgba(tt.want)); distance > 4.5 {
+			t.Errorf("Harmonize(%#x, %#x) = %#x, want %#x (ΔE %.1f)", tt.design, tt.source, got, tt.want, distance)
+		}
+	}
+}
+
+func TestHarmonizeRotation(t *testing.T) {
+	tests := []struct {
+		design   int
+		source   int
+		rotation float64
+	}{
+		{0xff6750a4, 0xff006c4c, -15}, // the hues are 132° apart, the rotation is capped
+		{0xff00897b, 0xff6750a4, 15},
+		{0xffb3261e, 0xff6750a4, -15},
+		{0xff6750a4, 0xff5b5d72, -9.65}, // the hues are 19.3° apart, the rotation is half of it
+	}
+	for _, tt := range tests {
+		from := hctFromInt(tt.design)
+		got := hctFromInt(Harmonize(tt.design, tt.source))
+		want := sanitizeDegrees(from.hue + tt.rotation)
+		if differenceDegrees(got.hue, want) > 0.5 || math.Abs(got.tone-from.tone) > 0.5 {
+			t.Errorf("Harmonize(%#x, %#x) has hue %.1f and tone %.1f, want %.1f and %.1f",
+				tt.design, tt.source, got.hue, got.tone, want, from.tone)
+		}
+	}
+}
+
+func TestWithHarmonization(t *testing.T) {
+	custom := palettes.NewTonalPaletteFromInt(0xff00897b)
+	s := FromSeed(0xff6750a4, VariantTonalSpot, false).WithCustomTonalPalette(custom, false)
+
+	h := s.WithHarmonization(true, false)
+	want := Harmonize(custom.GetKeyColor().ToInt(), s.primaryTone.GetKeyColor().ToInt())
+	if got := h.customTone.GetKeyColor().ToInt(); got != palettes.NewTonalPaletteFromInt(want).GetKeyColor().ToInt() {
+		t.Errorf("harmonized custom key color is %#x, want the key color of %#x", got, want)
+	}
+	if h.Custom == s.Custom {
+		t.Error("custom color not harmonized")
+	}
+	if h.errorTone != s.errorTone || h.Error != s.Error {
+		t.Error("error palette harmonized")
+	}
+
+	// Without harmonization, the palettes as set are used again.
+	if back := h.WithHarmonization(false, false); back.Custom != s.Custom || back.customTone != custom {
+		t.Errorf("custom color is %s without harmonization, want %s", Hex(back.Custom), Hex(s.Custom))
+	}
+}
+
+func TestHarmonizationFollowsPrimary(t *testing.T) {
+	custom := palettes.NewTonalPaletteFromInt(0xff00897b)
+	primary := palettes.NewTonalPaletteFromInt(0xffb3261e)
+	s := FromSeed(0xff6750a4, VariantTonalSpot, true).
+		WithCustomTonalPalette(custom, true).
+		WithHarmonization(true, true)
+
+	got := s.WithPrimaryTonalPalette(primary, true)
+	want := FromSeed(0xff6750a4, VariantTonalSpot, true).
+		WithCustomTonalPalette(custom, true).
+		WithPrimaryTonalPalette(primary, true).
+		WithHarmonization(true, true)
+	for _, role := range []Role{RoleCustom, RoleOnCustom, RoleCustomContainer, RoleError, RoleErrorContainer} {
+		w, _ := want.Color(role)
+		if g, _ := got.Color(role); g != w {
+			t.Errorf("%s is %s after a new primary palette, want %s", role, Hex(g), Hex(w))
+		}
+	}
+	if got.Custom == s.Custom {
+		t.Error("custom color did not follow the primary palette")
+	}
+	if got.customSource != custom {
+		t.Error("custom palette as set not kept")
+	}
+}
@@ -0,0 +1,164 @@
+// Copyright (c) 2023 https://github.com/gio-eui
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR a PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+//
+// SPDX-License-Identifier: MIT
+
+package scheme
+
+import (
+	"image/color"
+	"math"
+)
+
+// Contrast levels of a scheme, from -1 (reduced) to 1 (high).
+// Any value in between can be used as well.
+const (
+	ContrastReduced  = -1.0
+	ContrastStandard = 0.0
+	ContrastMedium   = 0.5
+	ContrastHigh     = 1.0
+)
+
+// ContrastCurve holds the contrast ratios a color must reach at the reduced, standard,
+// medium and high contrast levels. Levels in between are linearly interpolated.
+type ContrastCurve struct {
+	Low    float64 // contrast level -1
+	Normal float64 // contrast level 0
+	Medium float64 // contrast level 0.5
+	High   float64 // contrast level 1
+}
+
+// Get returns the value of the curve at the given contrast level.
+func (c ContrastCurve) Get(contrastLevel float64) float64 {
+	switch {
+	case contrastLevel <= -1.0:
+		return c.Low
+	case contrastLevel < 0.0:
+		return lerp(c.Low, c.Normal, contrastLevel+1)
+	case contrastLevel < 0.5:
+		return lerp(c.Normal, c.Medium, contrastLevel/0.5)
+	case contrastLevel < 1.0:
+		return lerp(c.Medium, c.High, (contrastLevel-0.5)/0.5)
+	default:
+		return c.High
+	}
+}
+
+// ContrastRatio returns the WCAG 2 contrast ratio of two colors, from 1 to 21.
+// The alpha of the colors is ignored.
+func ContrastRatio(a color.NRGBA, b color.NRGBA) float64 {
+	a.A, b.A = 0xff, 0xff
+	return ratioOfYs(yFromLstar(lstarFromArgb(Argb(a))), yFromLstar(lstarFromArgb(Argb(b))))
+}
+
+// ratioOfTones returns the WCAG contrast ratio of two tones.
+func ratioOfTones(t1 float64, t2 float64) float64 {
+	return ratioOfYs(yFromLstar(clamp(0, 100, t1)), yFromLstar(clamp(0, 100, t2)))
+}
+
+// ratioOfYs returns the WCAG contrast ratio of two relative luminances in [0, 100].
+func ratioOfYs(y1 float64, y2 float64) float64 {
+	lighter := math.Max(y1, y2)
+	darker := math.Min(y1, y2)
+	return (lighter + 5.0) / (darker + 5.0)
+}
+
+// lighterTone returns a tone greater than or equal to tone that reaches the contrast ratio,
+// or -1 if there is none.
+func lighterTone(tone float64, ratio float64) float64 {
+	if tone < 0.0 || tone > 100.0 {
+		return -1.0
+	}
+	darkY := yFromLstar(tone)
+	lightY := ratio*(darkY+5.0) - 5.0
+	realContrast := ratioOfYs(lightY, darkY)
+	if realContrast < ratio && math.Abs(realContrast-ratio) > 0.04 {
+		return -1.0
+	}
+	// Add a small margin so that rounding the tone does not break the ratio.
+	lighter := lstarFromY(lightY) + 0.4
+	if lighter < 0 || lighter > 100 {
+		return -1.0
+	}
+	return lighter
+}
+
+// darkerTone returns a tone less than or equal to tone that reaches the contrast ratio,
+// or -1 if there is none.
+func darkerTone(tone float64, ratio float64) float64 {
+	if tone < 0.0 || tone > 100.0 {
+		return -1.0
+	}
+	lightY := yFromLstar(tone)
+	darkY := (lightY+5.0)/ratio - 5.0
+	realContrast := ratioOfYs(lightY, darkY)
+	if realContrast < ratio && math.Abs(realContrast-ratio) > 0.04 {
+		return -1.0
+	}
+	// Subtract a small margin so that rounding the tone does not break the ratio.
+	darker := lstarFromY(darkY) - 0.4
+	if darker < 0 || darker > 100 {
+		return -1.0
+	}
+	return darker
+}
+
+// lighterToneUnsafe is like lighterTone, but returns 100 if the ratio cannot be reached.
+func lighterToneUnsafe(tone float64, ratio float64) float64 {
+	lighter := lighterTone(tone, ratio)
+	if lighter < 0.0 {
+		return 100.0
+	}
+	return lighter
+}
+
+// darkerToneUnsafe is like darkerTone, but returns 0 if the ratio cannot be reached.
+func darkerToneUnsafe(tone float64, ratio float64) float64 {
+	darker := darkerTone(tone, ratio)
+	if darker < 0.0 {
+		return 0.0
+	}
+	return darker
+}
+
+// foregroundTone returns the tone reaching the contrast ratio against a background tone,
+// preferring a light foreground on dark backgrounds and a dark one on light backgrounds.
+func foregroundTone(backgroundTone float64, ratio float64) float64 {
+	lighter := lighterToneUnsafe(backgroundTone, ratio)
+	darker := darkerToneUnsafe(backgroundTone, ratio)
+	lighterRatio := ratioOfTones(lighter, backgroundTone)
+	darkerRatio := ratioOfTones(darker, backgroundTone)
+	if tonePrefersLightForeground(backgroundTone) {
+		// Keep the lighter tone when both fail by about the same amount.
+		negligibleDifference := math.Abs(lighterRatio-darkerRatio) < 0.1 && lighterRatio < ratio && darkerRatio < ratio
+		if lighterRatio >= ratio || lighterRatio >= darkerRatio || negligibleDifference {
+			return lighter
+		}
+		return darker
+	}
+	if darkerRatio >= ratio || darkerRatio >= lighterRatio {
+		return darker
+	}
+	return lighter
+}
+
+// tonePrefersLightForeground reports whether a light foreground reads better on the tone.
+func tonePrefersLightForeground(tone float64) bool {
+	return math.Round(tone) < 60.0
+}
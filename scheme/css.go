@@ -0,0 +1,94 @@
+// Copyright (c) 2023 https://github.com/gio-eui
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR a PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+//
+// SPDX-License-Identifier: MIT
+
+package scheme
+
+import (
+	"bufio"
+	"fmt"
+	"image/color"
+	"io"
+	"strings"
+	"unicode"
+)
+
+// CSSName returns the name of the CSS custom property of the role, following the MD3 token
+// names, such as "--md-sys-color-on-primary-container".
+func (r Role) CSSName() string {
+	var b strings.Builder
+	b.WriteString("--md-sys-color-")
+	for i, c := range string(r) {
+		if unicode.IsUpper(c) {
+			if i > 0 {
+				b.WriteByte('-')
+			}
+			c = unicode.ToLower(c)
+		}
+		b.WriteRune(c)
+	}
+	return b.String()
+}
+
+// HasCustomColor reports whether the custom color roles of the scheme are set.
+func (s *Scheme) HasCustomColor() bool {
+	return s.customTone != nil || s.Custom != (color.NRGBA{})
+}
+
+// WriteCSS writes the colors of the scheme as CSS custom properties of a rule
+// with the given selector, such as ":root". The custom roles are only written
+// when the scheme has a custom color. Extended colors are named like Material Theme
+// Builder does, such as --md-extended-color-success-on-color.
+func (s *Scheme) WriteCSS(w io.Writer, selector string) error {
+	return s.WriteCSSRule(w, selector, "")
+}
+
+// WriteCSSRule writes the colors of the scheme like WriteCSS, with every line of the rule
+// indented by indent, such as to nest it in a media query.
+func (s *Scheme) WriteCSSRule(w io.Writer, selector string, indent string) error {
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, "%s%s {\n", indent, selector)
+	for _, entry := range roles {
+		if !s.HasCustomColor() && entry.role.IsCustom() {
+			continue
+		}
+		fmt.Fprintf(bw, "%s  %s: %s;\n", indent, entry.role.CSSName(), Hex(*entry.field(s)))
+	}
+	for _, e := range s.extended {
+		prefix := "--md-extended-color-" + cssIdent(e.Name)
+		fmt.Fprintf(bw, "%s  %s-color: %s;\n", indent, prefix, Hex(e.Color))
+		fmt.Fprintf(bw, "%s  %s-on-color: %s;\n", indent, prefix, Hex(e.OnColor))
+		fmt.Fprintf(bw, "%s  %s-color-container: %s;\n", indent, prefix, Hex(e.ColorContainer))
+		fmt.Fprintf(bw, "%s  %s-on-color-container: %s;\n", indent, prefix, Hex(e.OnColorContainer))
+	}
+	fmt.Fprintf(bw, "%s}\n", indent)
+	return bw.Flush()
+}
+
+// cssIdent converts a name to a lowercase CSS identifier, replacing any character other than
+// letters and digits by a dash.
+func cssIdent(name string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return unicode.ToLower(r)
+		}
+		return '-'
+	}, name)
+}
@@ -0,0 +1,55 @@
+// Copyright (c) 2023 https://github.com/gio-eui
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR a PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+//
+// SPDX-License-Identifier: MIT
+
+package scheme
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gio-eui/md3-colors/palettes"
+)
+
+func TestWriteCSSRule(t *testing.T) {
+	s := FromSeed(0xff6750a4, VariantTonalSpot, false).
+		WithExtendedColor("Success Green", palettes.NewTonalPaletteFromInt(0xff2e7d32), false)
+	var b strings.Builder
+	if err := s.WriteCSSRule(&b, ":root", "  "); err != nil {
+		t.Fatal(err)
+	}
+	css := b.String()
+	e, _ := s.ExtendedColor("Success Green")
+	for _, want := range []string{
+		"  :root {\n",
+		"    --md-sys-color-primary: " + Hex(s.Primary) + ";\n",
+		"    --md-sys-color-on-primary-container: " + Hex(s.OnPrimaryContainer) + ";\n",
+		"    --md-extended-color-success-green-on-color: " + Hex(e.OnColor) + ";\n",
+		"    --md-extended-color-success-green-color-container: " + Hex(e.ColorContainer) + ";\n",
+		"  }\n",
+	} {
+		if !strings.Contains(css, want) {
+			t.Errorf("missing %q in:\n%s", want, css)
+		}
+	}
+	if strings.Contains(css, "--md-sys-color-custom") {
+		t.Errorf("custom roles written for a scheme without a custom color:\n%s", css)
+	}
+}
@@ -0,0 +1,243 @@
+// Copyright (c) 2023 https://github.com/gio-eui
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR a PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+//
+// SPDX-License-Identifier: MIT
+
+package scheme
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+	"strings"
+)
+
+// Deficiency is a color vision deficiency.
+type Deficiency int
+
+const (
+	DeficiencyProtanopia    Deficiency = iota // no red cones
+	DeficiencyDeuteranopia                    // no green cones
+	DeficiencyTritanopia                      // no blue cones
+	DeficiencyAchromatopsia                   // no color vision
+)
+
+// Deficiencies are all the color vision deficiencies, in declaration order.
+var Deficiencies = []Deficiency{
+	DeficiencyProtanopia,
+	DeficiencyDeuteranopia,
+	DeficiencyTritanopia,
+	DeficiencyAchromatopsia,
+}
+
+// String returns the name of the deficiency.
+func (d Deficiency) String() string {
+	switch d {
+	case DeficiencyProtanopia:
+		return "protanopia"
+	case DeficiencyDeuteranopia:
+		return "deuteranopia"
+	case DeficiencyTritanopia:
+		return "tritanopia"
+	case DeficiencyAchromatopsia:
+		return "achromatopsia"
+	default:
+		return fmt.Sprintf("Deficiency(%d)", int(d))
+	}
+}
+
+// deficiencyMatrices are the Machado et al. (2009) simulation matrices at full severity,
+// applied to linear RGB.
+var deficiencyMatrices = map[Deficiency][3][3]float64{
+	DeficiencyProtanopia: {
+		{0.152286, 1.052583, -0.204868},
+		{0.114503, 0.786281, 0.099216},
+		{-0.003882, -0.048116, 1.051998},
+	},
+	DeficiencyDeuteranopia: {
+		{0.367322, 0.860646, -0.227968},
+		{0.280085, 0.672501, 0.047413},
+		{-0.011820, 0.042940, 0.968881},
+	},
+	DeficiencyTritanopia: {
+		{1.255528, -0.076749, -0.178779},
+		{-0.078411, 0.930809, 0.147602},
+		{0.004733, 0.691367, 0.303900},
+	},
+	DeficiencyAchromatopsia: {
+		{0.2126, 0.7152, 0.0722},
+		{0.2126, 0.7152, 0.0722},
+		{0.2126, 0.7152, 0.0722},
+	},
+}
+
+// SimulateDeficiency returns the color as seen with the color vision deficiency, using the
+// Machado et al. (2009) simulation, or the luminance of the color for achromatopsia.
+// The alpha of the color is kept.
+func SimulateDeficiency(c color.NRGBA, d Deficiency) color.NRGBA {
+	m, ok := deficiencyMatrices[d]
+	if !ok {
+		return c
+	}
+	r, g, b := linearized(int(c.R)), linearized(int(c.G)), linearized(int(c.B))
+	return color.NRGBA{
+		R: uint8(delinearized(m[0][0]*r + m[0][1]*g + m[0][2]*b)),
+		G: uint8(delinearized(m[1][0]*r + m[1][1]*g + m[1][2]*b)),
+		B: uint8(delinearized(m[2][0]*r + m[2][1]*g + m[2][2]*b)),
+		A: c.A,
+	}
+}
+
+// SimulateDeficiency returns a new scheme whose colors are the colors of the scheme as seen
+// with the color vision deficiency. The new scheme has no tonal palettes, so its colors are
+// not regenerated by WithContrastLevel.
+func (s *Scheme) SimulateDeficiency(d Deficiency) *Scheme {
+	sim := s.colorsOnly()
+
+	for _, entry := range roles {
+		field := entry.field(sim)
+		*field = SimulateDeficiency(*field, d)
+	}
+	sim.extended = make([]ExtendedColor, len(s.extended))
+	for i, e := range s.extended {
+		sim.extended[i] = ExtendedColor{
+			Name:             e.Name,
+			Color:            SimulateDeficiency(e.Color, d),
+			OnColor:          SimulateDeficiency(e.OnColor, d),
+			ColorContainer:   SimulateDeficiency(e.ColorContainer, d),
+			OnColorContainer: SimulateDeficiency(e.OnColorContainer, d),
+		}
+	}
+	for _, field := range toneFields(sim) {
+		*field = SimulateDeficiency(*field, d)
+	}
+	return sim
+}
+
+// DefaultMinColorDistance is the default minimum CIELAB distance (ΔE*ab) under which two
+// colors are hard to tell apart.
+const DefaultMinColorDistance = 10.0
+
+// RolePair is a pair of roles that must remain distinguishable from each other.
+type RolePair struct {
+	A Role
+	B Role
+}
+
+// String returns the pair as "a/b".
+func (p RolePair) String() string {
+	return fmt.Sprintf("%s/%s", p.A, p.B)
+}
+
+// distinguishablePairs are the role pairs of a scheme conveying different meanings.
+var distinguishablePairs = []RolePair{
+	{RoleError, RolePrimary},
+	{RoleError, RoleSecondary},
+	{RoleError, RoleTertiary},
+	{RolePrimary, RoleTertiary},
+	{RoleErrorContainer, RolePrimaryContainer},
+	{RoleErrorContainer, RoleTertiaryContainer},
+	{RolePrimaryContainer, RoleTertiaryContainer},
+	{RoleCustom, RolePrimary},
+	{RoleCustom, RoleError},
+	{RoleCustomContainer, RolePrimaryContainer},
+	{RoleCustomContainer, RoleErrorContainer},
+}
+
+// DistinguishablePairs returns the role pairs of the scheme that convey different meanings,
+// such as error and primary. The custom pairs are left out when the scheme has no custom color.
+// Each extended color is paired with primary and error like the custom color, and with every
+// other extended color.
+func (s *Scheme) DistinguishablePairs() []RolePair {
+	pairs := make([]RolePair, 0, len(distinguishablePairs))
+	for _, pair := range distinguishablePairs {
+		if (pair.A.IsCustom() || pair.B.IsCustom()) && !s.HasCustomColor() {
+			continue
+		}
+		pairs = append(pairs, pair)
+	}
+	for i, e := range s.extended {
+		r := e.Roles()
+		pairs = append(pairs,
+			RolePair{r[0], RolePrimary},
+			RolePair{r[0], RoleError},
+			RolePair{r[2], RolePrimaryContainer},
+			RolePair{r[2], RoleErrorContainer},
+		)
+		for _, other := range s.extended[i+1:] {
+			o := other.Roles()
+			pairs = append(pairs, RolePair{r[0], o[0]}, RolePair{r[2], o[2]})
+		}
+	}
+	return pairs
+}
+
+// DistinguishabilityIssue is a role pair whose colors collapse with a color vision deficiency.
+type DistinguishabilityIssue struct {
+	RolePair
+	Deficiency Deficiency
+	Distance   float64 // CIELAB distance of the simulated colors
+}
+
+// String describes the issue.
+func (i DistinguishabilityIssue) String() string {
+	return fmt.Sprintf("%s with %s (ΔE %.1f)", i.RolePair, i.Deficiency, i.Distance)
+}
+
+// CheckDistinguishability simulates every color vision deficiency and returns the role pairs
+// whose simulated colors are closer than minDistance in CIELAB. When pairs is nil, the pairs
+// of DistinguishablePairs are checked.
+func (s *Scheme) CheckDistinguishability(pairs []RolePair, minDistance float64) []DistinguishabilityIssue {
+	if pairs == nil {
+		pairs = s.DistinguishablePairs()
+	}
+	var issues []DistinguishabilityIssue
+	for _, d := range Deficiencies {
+		for _, pair := range pairs {
+			a, okA := s.Color(pair.A)
+			b, okB := s.Color(pair.B)
+			if !okA || !okB {
+				continue
+			}
+			distance := colorDistance(SimulateDeficiency(a, d), SimulateDeficiency(b, d))
+			if distance < minDistance {
+				issues = append(issues, DistinguishabilityIssue{RolePair: pair, Deficiency: d, Distance: distance})
+			}
+		}
+	}
+	return issues
+}
+
+// ParseDeficiency returns the deficiency with the given name, as returned by String.
+// The name is case-insensitive.
+func ParseDeficiency(name string) (Deficiency, error) {
+	for _, d := range Deficiencies {
+		if strings.EqualFold(name, d.String()) {
+			return d, nil
+		}
+	}
+	return 0, fmt.Errorf("scheme: unknown deficiency %q", name)
+}
+
+// colorDistance returns the CIELAB distance (ΔE*ab) of two colors.
+func colorDistance(a color.NRGBA, b color.NRGBA) float64 {
+	l1, a1, b1 := labFromArgb(Argb(a))
+	l2, a2, b2 := labFromArgb(Argb(b))
+	return math.Sqrt((l1-l2)*(l1-l2) + (a1-a2)*(a1-a2) + (b1-b2)*(b1-b2))
+}
@@ -0,0 +1,117 @@
+// Copyright (c) 2023 https://github.com/gio-eui
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR a PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+//
+// SPDX-License-Identifier: MIT
+
+package scheme
+
+import (
+	"image/color"
+	"testing"
+
+	"github.com/gio-eui/md3-colors/palettes"
+)
+
+func TestSimulateDeficiencyGray(t *testing.T) {
+	gray := color.NRGBA{R: 0x80, G: 0x80, B: 0x80, A: 0x40}
+	for _, d := range Deficiencies {
+		got := SimulateDeficiency(gray, d)
+		if distance := colorDistance(got, gray); distance > 1 || got.A != gray.A {
+			t.Errorf("%s: gray simulated as %s", d, Hex(got))
+		}
+	}
+}
+
+func TestSimulateDeficiencyRedGreen(t *testing.T) {
+	red := color.NRGBA{R: 0xd3, G: 0x2f, B: 0x2f, A: 0xff}
+	green := color.NRGBA{R: 0x38, G: 0x8e, B: 0x3c, A: 0xff}
+	original := colorDistance(red, green)
+	for _, d := range []Deficiency{DeficiencyProtanopia, DeficiencyDeuteranopia} {
+		simulated := colorDistance(SimulateDeficiency(red, d), SimulateDeficiency(green, d))
+		if simulated > original/2 {
+			t.Errorf("%s: red and green ΔE %.1f, want much less than %.1f", d, simulated, original)
+		}
+	}
+	// Blue cones tell red and green apart well enough.
+	if simulated := colorDistance(SimulateDeficiency(red, DeficiencyTritanopia), SimulateDeficiency(green, DeficiencyTritanopia)); simulated < DefaultMinColorDistance {
+		t.Errorf("tritanopia: red and green ΔE %.1f", simulated)
+	}
+	// Achromatopsia keeps only the luminance.
+	sim := SimulateDeficiency(red, DeficiencyAchromatopsia)
+	if sim.R != sim.G || sim.G != sim.B {
+		t.Errorf("achromatopsia: red simulated as %s", Hex(sim))
+	}
+}
+
+func TestSchemeSimulateDeficiency(t *testing.T) {
+	s := FromSeed(0xff6750a4, VariantTonalSpot, false)
+	sim := s.SimulateDeficiency(DeficiencyAchromatopsia)
+	for _, entry := range roles {
+		c := *entry.field(sim)
+		if c.R != c.G || c.G != c.B {
+			t.Errorf("%s: %s is not gray", entry.role, Hex(c))
+		}
+	}
+	if primary, _ := s.Color(RolePrimary); primary == sim.Primary {
+		t.Error("SimulateDeficiency changed the original scheme")
+	}
+}
+
+func TestParseDeficiency(t *testing.T) {
+	for _, d := range Deficiencies {
+		got, err := ParseDeficiency(d.String())
+		if err != nil || got != d {
+			t.Errorf("ParseDeficiency(%q) = %v, %v", d, got, err)
+		}
+	}
+	if got, err := ParseDeficiency("Deuteranopia"); err != nil || got != DeficiencyDeuteranopia {
+		t.Errorf("ParseDeficiency is case-sensitive: %v, %v", got, err)
+	}
+	if _, err := ParseDeficiency("dichromacy"); err == nil {
+		t.Error("no error for an unknown deficiency")
+	}
+}
+
+func TestCheckDistinguishabilityExtendedColors(t *testing.T) {
+	s := FromSeed(0xff6750a4, VariantTonalSpot, false)
+	// An extended color from the primary key color cannot be told apart from primary.
+	s = s.WithExtendedColor("brand", palettes.NewTonalPaletteFromInt(0xff6750a4), false).
+		WithExtendedColor("success", palettes.NewTonalPaletteFromInt(0xff2e7d32), false)
+
+	brand := RolePair{ExtendedRole("brand", "color"), RolePrimary}
+	var found bool
+	for _, pair := range s.DistinguishablePairs() {
+		found = found || pair == brand
+	}
+	if !found {
+		t.Fatalf("missing pair %s", brand)
+	}
+
+	issues := make(map[RolePair]bool)
+	for _, issue := range s.CheckDistinguishability(nil, DefaultMinColorDistance) {
+		issues[issue.RolePair] = true
+	}
+	if !issues[brand] {
+		t.Errorf("no issue for %s", brand)
+	}
+	// Green and red collapse with a red-green deficiency.
+	if pair := (RolePair{ExtendedRole("success", "color"), RoleError}); !issues[pair] {
+		t.Errorf("no issue for %s", pair)
+	}
+}
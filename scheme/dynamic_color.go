@@ -0,0 +1,193 @@
+// Copyright (c) 2023 https://github.com/gio-eui
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR a PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+//
+// SPDX-License-Identifier: MIT
+
+package scheme
+
+import (
+	"math"
+
+	"github.com/gio-eui/md3-colors/palettes"
+)
+
+// TonePolarity describes which role of a ToneDeltaPair is expected to be lighter.
+type TonePolarity int
+
+const (
+	// TonePolarityDarker makes the first role darker than the second one.
+	TonePolarityDarker TonePolarity = iota
+	// TonePolarityLighter makes the first role lighter than the second one.
+	TonePolarityLighter
+	// TonePolarityNearer makes the first role nearer to the background than the second one.
+	TonePolarityNearer
+	// TonePolarityFarther makes the first role farther from the background than the second one.
+	TonePolarityFarther
+)
+
+// ToneDeltaPair constrains two roles sharing a background to keep a minimum tone difference,
+// such as a container and the accent color drawn on top of the same surface.
+type ToneDeltaPair struct {
+	RoleA        *DynamicColor
+	RoleB        *DynamicColor
+	Delta        float64
+	Polarity     TonePolarity
+	StayTogether bool // keep both roles on the same side of the 50-60 tone range
+}
+
+// DynamicColor is a color role defined by the palette it takes its color from and by the
+// tone it should have. When it has a background, the tone is adjusted to reach the contrast
+// ratio given by its curve at the contrast level of the scheme.
+type DynamicColor struct {
+	Name             string
+	Palette          func(s *Scheme) *palettes.TonalPalette
+	Tone             func(s *Scheme) float64
+	IsBackground     bool
+	Background       func(s *Scheme) *DynamicColor
+	SecondBackground func(s *Scheme) *DynamicColor
+	ContrastCurve    *ContrastCurve
+	ToneDeltaPair    func(s *Scheme) *ToneDeltaPair
+}
+
+// GetArgb returns the color of the role in the scheme, as an int representing an argb color.
+func (c *DynamicColor) GetArgb(s *Scheme) int {
+	return c.Palette(s).Tone(int(math.Round(c.GetTone(s))))
+}
+
+// GetTone returns the tone of the role in the scheme, solving its contrast constraints.
+func (c *DynamicColor) GetTone(s *Scheme) float64 {
+	decreasingContrast := s.contrastLevel < 0
+
+	if c.ToneDeltaPair != nil {
+		pair := c.ToneDeltaPair(s)
+		bgTone := c.Background(s).GetTone(s)
+
+		aIsNearer := pair.Polarity == TonePolarityNearer ||
+			(pair.Polarity == TonePolarityLighter && !s.isDark) ||
+			(pair.Polarity == TonePolarityDarker && s.isDark)
+		nearer, farther := pair.RoleA, pair.RoleB
+		if !aIsNearer {
+			nearer, farther = pair.RoleB, pair.RoleA
+		}
+		amNearer := c.Name == nearer.Name
+		expansionDir := -1.0
+		if s.isDark {
+			expansionDir = 1.0
+		}
+
+		// Each role first reaches its own contrast against the background.
+		nContrast := nearer.ContrastCurve.Get(s.contrastLevel)
+		fContrast := farther.ContrastCurve.Get(s.contrastLevel)
+		nTone := nearer.Tone(s)
+		if ratioOfTones(bgTone, nTone) < nContrast || decreasingContrast {
+			nTone = foregroundTone(bgTone, nContrast)
+		}
+		fTone := farther.Tone(s)
+		if ratioOfTones(bgTone, fTone) < fContrast || decreasingContrast {
+			fTone = foregroundTone(bgTone, fContrast)
+		}
+
+		// Then the farther role moves away from the nearer one, or the nearer one
+		// moves back when the farther one hits the end of the tone range.
+		if (fTone-nTone)*expansionDir < pair.Delta {
+			fTone = clamp(0, 100, nTone+pair.Delta*expansionDir)
+			if (fTone-nTone)*expansionDir < pair.Delta {
+				nTone = clamp(0, 100, fTone-pair.Delta*expansionDir)
+			}
+		}
+
+		// Tones between 50 and 60 are avoided: they contrast poorly with both
+		// light and dark foregrounds.
+		if nTone >= 50 && nTone < 60 {
+			if expansionDir > 0 {
+				nTone = 60
+				fTone = math.Max(fTone, nTone+pair.Delta*expansionDir)
+			} else {
+				nTone = 49
+				fTone = math.Min(fTone, nTone+pair.Delta*expansionDir)
+			}
+		} else if fTone >= 50 && fTone < 60 {
+			if pair.StayTogether {
+				if expansionDir > 0 {
+					nTone = 60
+					fTone = math.Max(fTone, nTone+pair.Delta*expansionDir)
+				} else {
+					nTone = 49
+					fTone = math.Min(fTone, nTone+pair.Delta*expansionDir)
+				}
+			} else if expansionDir > 0 {
+				fTone = 60
+			} else {
+				fTone = 49
+			}
+		}
+
+		if amNearer {
+			return nTone
+		}
+		return fTone
+	}
+
+	answer := c.Tone(s)
+	if c.Background == nil {
+		return answer
+	}
+
+	bgTone := c.Background(s).GetTone(s)
+	desiredRatio := c.ContrastCurve.Get(s.contrastLevel)
+	if ratioOfTones(bgTone, answer) < desiredRatio || decreasingContrast {
+		answer = foregroundTone(bgTone, desiredRatio)
+	}
+	if c.IsBackground && answer >= 50 && answer < 60 {
+		if ratioOfTones(49, bgTone) >= desiredRatio {
+			answer = 49
+		} else {
+			answer = 60
+		}
+	}
+
+	if c.SecondBackground == nil {
+		return answer
+	}
+
+	// The role must contrast with two backgrounds, so the answer is searched
+	// above the lighter one or below the darker one.
+	bgTone1 := c.Background(s).GetTone(s)
+	bgTone2 := c.SecondBackground(s).GetTone(s)
+	upper := math.Max(bgTone1, bgTone2)
+	lower := math.Min(bgTone1, bgTone2)
+	if ratioOfTones(upper, answer) >= desiredRatio && ratioOfTones(lower, answer) >= desiredRatio {
+		return answer
+	}
+	lightOption := lighterTone(upper, desiredRatio)
+	darkOption := darkerTone(lower, desiredRatio)
+	if tonePrefersLightForeground(bgTone1) || tonePrefersLightForeground(bgTone2) {
+		if lightOption < 0 {
+			return 100
+		}
+		return lightOption
+	}
+	if lightOption >= 0 && darkOption < 0 {
+		return lightOption
+	}
+	if darkOption < 0 {
+		return 0
+	}
+	return darkOption
+}
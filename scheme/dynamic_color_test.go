@@ -0,0 +1,184 @@
+// Copyright (c) 2023 https://github.com/gio-eui
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR a PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+//
+// SPDX-License-Identifier: MIT
+
+package scheme
+
+import (
+	"fmt"
+	"math"
+	"testing"
+)
+
+// mcuBlue is the seed color of the scheme tests of Material Color Utilities.
+const mcuBlue = 0xff0000ff
+
+func TestDynamicColorsMatchMaterialColorUtilities(t *testing.T) {
+	// The expected colors are the outputs of Material Color Utilities for the same seed.
+	// The key colors of the palettes are rounded to ARGB before the tonal palettes are
+	// built from them, so the colors are compared within a small distance instead of exactly.
+	const maxDistance = 2.0
+	tests := []struct {
+		variant       Variant
+		isDark        bool
+		contrastLevel float64
+		role          Role
+		want          int
+	}{
+		{VariantTonalSpot, false, 0, RolePrimary, 0xff555992},
+		{VariantTonalSpot, false, 0, RolePrimaryContainer, 0xffe0e0ff},
+		{VariantTonalSpot, false, 0, RoleSecondary, 0xff5c5d72},
+		{VariantTonalSpot, false, 0, RoleTertiary, 0xff78536b},
+		{VariantTonalSpot, false, 0, RoleSurface, 0xfffbf8ff},
+		{VariantTonalSpot, false, 1, RoleOnPrimaryContainer, 0xffffffff},
+		{VariantTonalSpot, false, 1, RoleSurface, 0xfffbf8ff},
+		{VariantTonalSpot, true, 0, RolePrimary, 0xffbec2ff},
+		{VariantTonalSpot, true, 0, RolePrimaryContainer, 0xff3e4278},
+		{VariantTonalSpot, true, 0, RoleOnPrimaryContainer, 0xffe0e0ff},
+		{VariantTonalSpot, true, 1, RoleOnPrimaryContainer, 0xff000000},
+		{VariantMonochrome, false, 0, RolePrimary, 0xff000000},
+		{VariantMonochrome, true, 0, RolePrimary, 0xffffffff},
+	}
+	for _, test := range tests {
+		name := fmt.Sprintf("%v/dark=%t/contrast=%v/%s", test.variant, test.isDark, test.contrastLevel, test.role)
+		t.Run(name, func(t *testing.T) {
+			s := FromSeed(mcuBlue, test.variant, test.isDark).WithContrastLevel(test.contrastLevel)
+			got, _ := s.Color(test.role)
+			want := NRGBA(test.want)
+			if d := colorDistance(got, want); d > maxDistance {
+				t.Errorf("got %s, want %s (distance %.2f)", Hex(got), Hex(want), d)
+			}
+		})
+	}
+}
+
+func TestSecondaryContainerFidelity(t *testing.T) {
+	// The blue of the seed cannot keep its chroma at tone 90: the light container is darker.
+	s := FromSeed(mcuBlue, VariantFidelity, false)
+	key := hctFromInt(s.secondaryTone.GetKeyColor().ToInt())
+	tone := SecondaryContainerDynamicColor.Tone(s)
+	if tone >= 90 {
+		t.Errorf("light: tone %v, want less than 90", tone)
+	}
+	if got, at90 := hctFrom(key.hue, key.chroma, tone).chroma, hctFrom(key.hue, key.chroma, 90).chroma; got <= at90 {
+		t.Errorf("light: chroma %.1f at tone %v, want more than %.1f at tone 90", got, tone, at90)
+	}
+	// The dark container is only ever lighter.
+	if tone := SecondaryContainerDynamicColor.Tone(FromSeed(mcuBlue, VariantFidelity, true)); tone < 30 {
+		t.Errorf("dark: tone %v, want at least 30", tone)
+	}
+	// Other variants keep the tone.
+	if tone := SecondaryContainerDynamicColor.Tone(FromSeed(mcuBlue, VariantTonalSpot, false)); tone != 90 {
+		t.Errorf("tonal spot: tone %v, want 90", tone)
+	}
+}
+
+func TestDynamicColorsReachContrastCurves(t *testing.T) {
+	for _, s := range testSchemes() {
+		for _, c := range testDynamicColors() {
+			if c.Background == nil || c.ContrastCurve == nil || c.ToneDeltaPair != nil || c.SecondBackground != nil {
+				continue
+			}
+			tone := c.GetTone(s)
+			bgTone := c.Background(s).GetTone(s)
+			want := c.ContrastCurve.Get(s.contrastLevel)
+			// The ratio can only be reached if a tone in the range allows it.
+			want = math.Min(want, math.Max(ratioOfTones(bgTone, 0), ratioOfTones(bgTone, 100)))
+			if got := ratioOfTones(bgTone, tone); got < want-0.05 {
+				t.Errorf("%s: %s has a contrast of %.2f with %s, want at least %.2f",
+					testSchemeName(s), c.Name, got, c.Background(s).Name, want)
+			}
+		}
+	}
+}
+
+func TestDynamicColorsKeepToneDeltas(t *testing.T) {
+	for _, s := range testSchemes() {
+		for _, c := range testDynamicColors() {
+			if c.ToneDeltaPair == nil {
+				continue
+			}
+			pair := c.ToneDeltaPair(s)
+			a := pair.RoleA.GetTone(s)
+			b := pair.RoleB.GetTone(s)
+			if a < 0 || a > 100 || b < 0 || b > 100 {
+				t.Fatalf("%s: tones of %s and %s out of range: %.1f, %.1f",
+					testSchemeName(s), pair.RoleA.Name, pair.RoleB.Name, a, b)
+			}
+			// The delta is given up only when the tone range is exhausted.
+			if d := math.Abs(a - b); d < pair.Delta-0.01 && a != 0 && a != 100 && b != 0 && b != 100 {
+				t.Errorf("%s: tones of %s and %s are %.1f apart, want at least %.1f",
+					testSchemeName(s), pair.RoleA.Name, pair.RoleB.Name, d, pair.Delta)
+			}
+		}
+	}
+}
+
+func TestContrastLevelIncreasesContrast(t *testing.T) {
+	for _, isDark := range []bool{false, true} {
+		var previous float64
+		for _, level := range []float64{0, 0.5, 1} {
+			s := FromSeed(mcuBlue, VariantTonalSpot, isDark).WithContrastLevel(level)
+			ratio := ContrastRatio(s.Primary, s.Surface)
+			if ratio < previous {
+				t.Errorf("dark=%t: contrast level %v lowers the contrast of primary to %.2f, from %.2f",
+					isDark, level, ratio, previous)
+			}
+			previous = ratio
+		}
+	}
+}
+
+// testSchemes returns light and dark schemes of two seeds and several variants,
+// at the standard, medium and high contrast levels.
+func testSchemes() []*Scheme {
+	var schemes []*Scheme
+	for _, seed := range []int{mcuBlue, 0xff4285f4} {
+		for _, variant := range []Variant{VariantTonalSpot, VariantVibrant, VariantFidelity, VariantMonochrome} {
+			for _, isDark := range []bool{false, true} {
+				for _, level := range []float64{0, 0.5, 1} {
+					schemes = append(schemes, FromSeed(seed, variant, isDark).WithContrastLevel(level))
+				}
+			}
+		}
+	}
+	return schemes
+}
+
+func testSchemeName(s *Scheme) string {
+	return fmt.Sprintf("%v/dark=%t/contrast=%v", s.variant, s.isDark, s.contrastLevel)
+}
+
+func testDynamicColors() []*DynamicColor {
+	return []*DynamicColor{
+		BackgroundDynamicColor, OnBackgroundDynamicColor, SurfaceDynamicColor,
+		OnSurfaceDynamicColor, SurfaceVariantDynamicColor, OnSurfaceVariantDynamicColor,
+		InverseSurfaceDynamicColor, InverseOnSurfaceDynamicColor, OutlineDynamicColor,
+		OutlineVariantDynamicColor,
+		PrimaryDynamicColor, OnPrimaryDynamicColor, PrimaryContainerDynamicColor,
+		OnPrimaryContainerDynamicColor, InversePrimaryDynamicColor, PrimaryFixedDynamicColor,
+		PrimaryFixedDimDynamicColor, OnPrimaryFixedDynamicColor, OnPrimaryFixedVariantDynamicColor,
+		SecondaryDynamicColor, OnSecondaryDynamicColor, SecondaryContainerDynamicColor,
+		OnSecondaryContainerDynamicColor,
+		TertiaryDynamicColor, OnTertiaryDynamicColor, TertiaryContainerDynamicColor,
+		OnTertiaryContainerDynamicColor,
+		ErrorDynamicColor, OnErrorDynamicColor, ErrorContainerDynamicColor, OnErrorContainerDynamicColor,
+	}
+}
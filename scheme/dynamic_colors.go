@@ -0,0 +1,791 @@
+// Copyright (c) 2023 https://github.com/gio-eui
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR a PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+//
+// SPDX-License-Identifier: MIT
+
+package scheme
+
+import (
+	"math"
+
+	"github.com/gio-eui/md3-colors/palettes"
+)
+
+// Material dynamic colors: the roles of a scheme, evaluated against its tonal palettes.
+var (
+	BackgroundDynamicColor              *DynamicColor
+	OnBackgroundDynamicColor            *DynamicColor
+	SurfaceDynamicColor                 *DynamicColor
+	SurfaceDimDynamicColor              *DynamicColor
+	SurfaceBrightDynamicColor           *DynamicColor
+	SurfaceContainerLowestDynamicColor  *DynamicColor
+	SurfaceContainerLowDynamicColor     *DynamicColor
+	SurfaceContainerDynamicColor        *DynamicColor
+	SurfaceContainerHighDynamicColor    *DynamicColor
+	SurfaceContainerHighestDynamicColor *DynamicColor
+	OnSurfaceDynamicColor               *DynamicColor
+	SurfaceVariantDynamicColor          *DynamicColor
+	OnSurfaceVariantDynamicColor        *DynamicColor
+	InverseSurfaceDynamicColor          *DynamicColor
+	InverseOnSurfaceDynamicColor        *DynamicColor
+	OutlineDynamicColor                 *DynamicColor
+	OutlineVariantDynamicColor          *DynamicColor
+	ShadowDynamicColor                  *DynamicColor
+	ScrimDynamicColor                   *DynamicColor
+
+	PrimaryDynamicColor               *DynamicColor
+	OnPrimaryDynamicColor             *DynamicColor
+	PrimaryContainerDynamicColor      *DynamicColor
+	OnPrimaryContainerDynamicColor    *DynamicColor
+	InversePrimaryDynamicColor        *DynamicColor
+	PrimaryFixedDynamicColor          *DynamicColor
+	PrimaryFixedDimDynamicColor       *DynamicColor
+	OnPrimaryFixedDynamicColor        *DynamicColor
+	OnPrimaryFixedVariantDynamicColor *DynamicColor
+
+	SecondaryDynamicColor               *DynamicColor
+	OnSecondaryDynamicColor             *DynamicColor
+	SecondaryContainerDynamicColor      *DynamicColor
+	OnSecondaryContainerDynamicColor    *DynamicColor
+	SecondaryFixedDynamicColor          *DynamicColor
+	SecondaryFixedDimDynamicColor       *DynamicColor
+	OnSecondaryFixedDynamicColor        *DynamicColor
+	OnSecondaryFixedVariantDynamicColor *DynamicColor
+
+	TertiaryDynamicColor               *DynamicColor
+	OnTertiaryDynamicColor             *DynamicColor
+	TertiaryContainerDynamicColor      *DynamicColor
+	OnTertiaryContainerDynamicColor    *DynamicColor
+	TertiaryFixedDynamicColor          *DynamicColor
+	TertiaryFixedDimDynamicColor       *DynamicColor
+	OnTertiaryFixedDynamicColor        *DynamicColor
+	OnTertiaryFixedVariantDynamicColor *DynamicColor
+
+	CustomDynamicColor               *DynamicColor
+	OnCustomDynamicColor             *DynamicColor
+	CustomContainerDynamicColor      *DynamicColor
+	OnCustomContainerDynamicColor    *DynamicColor
+	CustomFixedDynamicColor          *DynamicColor
+	CustomFixedDimDynamicColor       *DynamicColor
+	OnCustomFixedDynamicColor        *DynamicColor
+	OnCustomFixedVariantDynamicColor *DynamicColor
+
+	ErrorDynamicColor            *DynamicColor
+	OnErrorDynamicColor          *DynamicColor
+	ErrorContainerDynamicColor   *DynamicColor
+	OnErrorContainerDynamicColor *DynamicColor
+)
+
+// The roles reference each other through their backgrounds and tone delta pairs,
+// so they are initialized here rather than in their declaration.
+func init() {
+	// ---------------------------------------------------------------------------------------------
+	// Neutral roles
+	// ---------------------------------------------------------------------------------------------
+
+	BackgroundDynamicColor = &DynamicColor{
+		Name:         "background",
+		Palette:      neutralPalette,
+		Tone:         func(s *Scheme) float64 { return toneFor(s, 6, 98) },
+		IsBackground: true,
+	}
+	OnBackgroundDynamicColor = &DynamicColor{
+		Name:          "onBackground",
+		Palette:       neutralPalette,
+		Tone:          func(s *Scheme) float64 { return toneFor(s, 90, 0) },
+		Background:    func(s *Scheme) *DynamicColor { return BackgroundDynamicColor },
+		ContrastCurve: &ContrastCurve{3, 3, 4.5, 7},
+	}
+	SurfaceDynamicColor = &DynamicColor{
+		Name:         "surface",
+		Palette:      neutralPalette,
+		Tone:         func(s *Scheme) float64 { return toneFor(s, 6, 98) },
+		IsBackground: true,
+	}
+	SurfaceDimDynamicColor = &DynamicColor{
+		Name:    "surfaceDim",
+		Palette: neutralPalette,
+		Tone: func(s *Scheme) float64 {
+			return toneFor(s, 6, ContrastCurve{87, 87, 80, 75}.Get(s.contrastLevel))
+		},
+		IsBackground: true,
+	}
+	SurfaceBrightDynamicColor = &DynamicColor{
+		Name:    "surfaceBright",
+		Palette: neutralPalette,
+		Tone: func(s *Scheme) float64 {
+			return toneFor(s, ContrastCurve{24, 24, 29, 34}.Get(s.contrastLevel), 98)
+		},
+		IsBackground: true,
+	}
+	SurfaceContainerLowestDynamicColor = &DynamicColor{
+		Name:    "surfaceContainerLowest",
+		Palette: neutralPalette,
+		Tone: func(s *Scheme) float64 {
+			return toneFor(s, ContrastCurve{4, 4, 2, 0}.Get(s.contrastLevel), 100)
+		},
+		IsBackground: true,
+	}
+	SurfaceContainerLowDynamicColor = &DynamicColor{
+		Name:    "surfaceContainerLow",
+		Palette: neutralPalette,
+		Tone: func(s *Scheme) float64 {
+			return toneFor(s, ContrastCurve{10, 10, 11, 12}.Get(s.contrastLevel), ContrastCurve{96, 96, 96, 95}.Get(s.contrastLevel))
+		},
+		IsBackground: true,
+	}
+	SurfaceContainerDynamicColor = &DynamicColor{
+		Name:    "surfaceContainer",
+		Palette: neutralPalette,
+		Tone: func(s *Scheme) float64 {
+			return toneFor(s, ContrastCurve{12, 12, 16, 20}.Get(s.contrastLevel), ContrastCurve{94, 94, 92, 90}.Get(s.contrastLevel))
+		},
+		IsBackground: true,
+	}
+	SurfaceContainerHighDynamicColor = &DynamicColor{
+		Name:    "surfaceContainerHigh",
+		Palette: neutralPalette,
+		Tone: func(s *Scheme) float64 {
+			return toneFor(s, ContrastCurve{17, 17, 21, 25}.Get(s.contrastLevel), ContrastCurve{92, 92, 88, 85}.Get(s.contrastLevel))
+		},
+		IsBackground: true,
+	}
+	SurfaceContainerHighestDynamicColor = &DynamicColor{
+		Name:    "surfaceContainerHighest",
+		Palette: neutralPalette,
+		Tone: func(s *Scheme) float64 {
+			return toneFor(s, ContrastCurve{22, 22, 26, 30}.Get(s.contrastLevel), ContrastCurve{90, 90, 84, 80}.Get(s.contrastLevel))
+		},
+		IsBackground: true,
+	}
+	OnSurfaceDynamicColor = &DynamicColor{
+		Name:          "onSurface",
+		Palette:       neutralPalette,
+		Tone:          func(s *Scheme) float64 { return toneFor(s, 90, 0) },
+		Background:    highestSurface,
+		ContrastCurve: &ContrastCurve{4.5, 7, 11, 21},
+	}
+	SurfaceVariantDynamicColor = &DynamicColor{
+		Name:         "surfaceVariant",
+		Palette:      neutralVariantPalette,
+		Tone:         func(s *Scheme) float64 { return toneFor(s, 30, 90) },
+		IsBackground: true,
+	}
+	OnSurfaceVariantDynamicColor = &DynamicColor{
+		Name:          "onSurfaceVariant",
+		Palette:       neutralVariantPalette,
+		Tone:          func(s *Scheme) float64 { return toneFor(s, 80, 30) },
+		Background:    highestSurface,
+		ContrastCurve: &ContrastCurve{3, 4.5, 7, 11},
+	}
+	InverseSurfaceDynamicColor = &DynamicColor{
+		Name:         "inverseSurface",
+		Palette:      neutralPalette,
+		Tone:         func(s *Scheme) float64 { return toneFor(s, 90, 20) },
+		IsBackground: true,
+	}
+	InverseOnSurfaceDynamicColor = &DynamicColor{
+		Name:          "inverseOnSurface",
+		Palette:       neutralPalette,
+		Tone:          func(s *Scheme) float64 { return toneFor(s, 20, 95) },
+		Background:    func(s *Scheme) *DynamicColor { return InverseSurfaceDynamicColor },
+		ContrastCurve: &ContrastCurve{4.5, 7, 11, 21},
+	}
+	OutlineDynamicColor = &DynamicColor{
+		Name:          "outline",
+		Palette:       neutralVariantPalette,
+		Tone:          func(s *Scheme) float64 { return toneFor(s, 60, 50) },
+		Background:    highestSurface,
+		ContrastCurve: &ContrastCurve{1.5, 3, 4.5, 7},
+	}
+	OutlineVariantDynamicColor = &DynamicColor{
+		Name:          "outlineVariant",
+		Palette:       neutralVariantPalette,
+		Tone:          func(s *Scheme) float64 { return toneFor(s, 30, 80) },
+		Background:    highestSurface,
+		ContrastCurve: &ContrastCurve{1, 1, 3, 4.5},
+	}
+	ShadowDynamicColor = &DynamicColor{
+		Name:    "shadow",
+		Palette: neutralPalette,
+		Tone:    func(s *Scheme) float64 { return 0 },
+	}
+	ScrimDynamicColor = &DynamicColor{
+		Name:    "scrim",
+		Palette: neutralPalette,
+		Tone:    func(s *Scheme) float64 { return 0 },
+	}
+
+	// ---------------------------------------------------------------------------------------------
+	// Primary roles
+	// ---------------------------------------------------------------------------------------------
+
+	PrimaryDynamicColor = &DynamicColor{
+		Name:    "primary",
+		Palette: primaryPalette,
+		Tone: func(s *Scheme) float64 {
+			if isMonochrome(s) {
+				return toneFor(s, 100, 0)
+			}
+			return toneFor(s, 80, 40)
+		},
+		IsBackground:  true,
+		Background:    highestSurface,
+		ContrastCurve: &ContrastCurve{3, 4.5, 7, 7},
+		ToneDeltaPair: func(s *Scheme) *ToneDeltaPair {
+			return &ToneDeltaPair{PrimaryContainerDynamicColor, PrimaryDynamicColor, 10, TonePolarityNearer, false}
+		},
+	}
+	OnPrimaryDynamicColor = &DynamicColor{
+		Name:    "onPrimary",
+		Palette: primaryPalette,
+		Tone: func(s *Scheme) float64 {
+			if isMonochrome(s) {
+				return toneFor(s, 10, 90)
+			}
+			return toneFor(s, 20, 100)
+		},
+		Background:    func(s *Scheme) *DynamicColor { return PrimaryDynamicColor },
+		ContrastCurve: &ContrastCurve{4.5, 7, 11, 21},
+	}
+	PrimaryContainerDynamicColor = &DynamicColor{
+		Name:    "primaryContainer",
+		Palette: primaryPalette,
+		Tone: func(s *Scheme) float64 {
+			if isFidelity(s) {
+				return hctFromInt(s.sourceColor).tone
+			}
+			if isMonochrome(s) {
+				return toneFor(s, 85, 25)
+			}
+			return toneFor(s, 30, 90)
+		},
+		IsBackground:  true,
+		Background:    highestSurface,
+		ContrastCurve: &ContrastCurve{1, 1, 3, 4.5},
+		ToneDeltaPair: func(s *Scheme) *ToneDeltaPair {
+			return &ToneDeltaPair{PrimaryContainerDynamicColor, PrimaryDynamicColor, 10, TonePolarityNearer, false}
+		},
+	}
+	OnPrimaryContainerDynamicColor = &DynamicColor{
+		Name:    "onPrimaryContainer",
+		Palette: primaryPalette,
+		Tone: func(s *Scheme) float64 {
+			if isFidelity(s) {
+				return foregroundTone(PrimaryContainerDynamicColor.Tone(s), 4.5)
+			}
+			if isMonochrome(s) {
+				return toneFor(s, 0, 100)
+			}
+			return toneFor(s, 90, 10)
+		},
+		Background:    func(s *Scheme) *DynamicColor { return PrimaryContainerDynamicColor },
+		ContrastCurve: &ContrastCurve{4.5, 7, 11, 21},
+	}
+	InversePrimaryDynamicColor = &DynamicColor{
+		Name:          "inversePrimary",
+		Palette:       primaryPalette,
+		Tone:          func(s *Scheme) float64 { return toneFor(s, 40, 80) },
+		Background:    func(s *Scheme) *DynamicColor { return InverseSurfaceDynamicColor },
+		ContrastCurve: &ContrastCurve{3, 4.5, 7, 7},
+	}
+	PrimaryFixedDynamicColor = &DynamicColor{
+		Name:    "primaryFixed",
+		Palette: primaryPalette,
+		Tone: func(s *Scheme) float64 {
+			if isMonochrome(s) {
+				return 40
+			}
+			return 90
+		},
+		IsBackground:  true,
+		Background:    highestSurface,
+		ContrastCurve: &ContrastCurve{1, 1, 3, 4.5},
+		ToneDeltaPair: func(s *Scheme) *ToneDeltaPair {
+			return &ToneDeltaPair{PrimaryFixedDynamicColor, PrimaryFixedDimDynamicColor, 10, TonePolarityLighter, true}
+		},
+	}
+	PrimaryFixedDimDynamicColor = &DynamicColor{
+		Name:    "primaryFixedDim",
+		Palette: primaryPalette,
+		Tone: func(s *Scheme) float64 {
+			if isMonochrome(s) {
+				return 30
+			}
+			return 80
+		},
+		IsBackground:  true,
+		Background:    highestSurface,
+		ContrastCurve: &ContrastCurve{1, 1, 3, 4.5},
+		ToneDeltaPair: func(s *Scheme) *ToneDeltaPair {
+			return &ToneDeltaPair{PrimaryFixedDynamicColor, PrimaryFixedDimDynamicColor, 10, TonePolarityLighter, true}
+		},
+	}
+	OnPrimaryFixedDynamicColor = &DynamicColor{
+		Name:    "onPrimaryFixed",
+		Palette: primaryPalette,
+		Tone: func(s *Scheme) float64 {
+			if isMonochrome(s) {
+				return 100
+			}
+			return 10
+		},
+		Background:       func(s *Scheme) *DynamicColor { return PrimaryFixedDimDynamicColor },
+		SecondBackground: func(s *Scheme) *DynamicColor { return PrimaryFixedDynamicColor },
+		ContrastCurve:    &ContrastCurve{4.5, 7, 11, 21},
+	}
+	OnPrimaryFixedVariantDynamicColor = &DynamicColor{
+		Name:    "onPrimaryFixedVariant",
+		Palette: primaryPalette,
+		Tone: func(s *Scheme) float64 {
+			if isMonochrome(s) {
+				return 90
+			}
+			return 30
+		},
+		Background:       func(s *Scheme) *DynamicColor { return PrimaryFixedDimDynamicColor },
+		SecondBackground: func(s *Scheme) *DynamicColor { return PrimaryFixedDynamicColor },
+		ContrastCurve:    &ContrastCurve{3, 4.5, 7, 11},
+	}
+
+	// ---------------------------------------------------------------------------------------------
+	// Secondary roles
+	// ---------------------------------------------------------------------------------------------
+
+	SecondaryDynamicColor = &DynamicColor{
+		Name:          "secondary",
+		Palette:       secondaryPalette,
+		Tone:          func(s *Scheme) float64 { return toneFor(s, 80, 40) },
+		IsBackground:  true,
+		Background:    highestSurface,
+		ContrastCurve: &ContrastCurve{3, 4.5, 7, 7},
+		ToneDeltaPair: func(s *Scheme) *ToneDeltaPair {
+			return &ToneDeltaPair{SecondaryContainerDynamicColor, SecondaryDynamicColor, 10, TonePolarityNearer, false}
+		},
+	}
+	OnSecondaryDynamicColor = &DynamicColor{
+		Name:    "onSecondary",
+		Palette: secondaryPalette,
+		Tone: func(s *Scheme) float64 {
+			if isMonochrome(s) {
+				return toneFor(s, 10, 100)
+			}
+			return toneFor(s, 20, 100)
+		},
+		Background:    func(s *Scheme) *DynamicColor { return SecondaryDynamicColor },
+		ContrastCurve: &ContrastCurve{4.5, 7, 11, 21},
+	}
+	SecondaryContainerDynamicColor = &DynamicColor{
+		Name:    "secondaryContainer",
+		Palette: secondaryPalette,
+		Tone: func(s *Scheme) float64 {
+			if isMonochrome(s) {
+				return toneFor(s, 30, 85)
+			}
+			if !isFidelity(s) {
+				return toneFor(s, 30, 90)
+			}
+			// Keep the chroma of the seed, as far as the tone allows
+			key := hctFromInt(secondaryPalette(s).GetKeyColor().ToInt())
+			return findDesiredChromaByTone(key.hue, key.chroma, toneFor(s, 30, 90), !s.isDark)
+		},
+		IsBackground:  true,
+		Background:    highestSurface,
+		ContrastCurve: &ContrastCurve{1, 1, 3, 4.5},
+		ToneDeltaPair: func(s *Scheme) *ToneDeltaPair {
+			return &ToneDeltaPair{SecondaryContainerDynamicColor, SecondaryDynamicColor, 10, TonePolarityNearer, false}
+		},
+	}
+	OnSecondaryContainerDynamicColor = &DynamicColor{
+		Name:    "onSecondaryContainer",
+		Palette: secondaryPalette,
+		Tone: func(s *Scheme) float64 {
+			if isFidelity(s) {
+				return foregroundTone(SecondaryContainerDynamicColor.Tone(s), 4.5)
+			}
+			return toneFor(s, 90, 10)
+		},
+		Background:    func(s *Scheme) *DynamicColor { return SecondaryContainerDynamicColor },
+		ContrastCurve: &ContrastCurve{4.5, 7, 11, 21},
+	}
+	SecondaryFixedDynamicColor = &DynamicColor{
+		Name:    "secondaryFixed",
+		Palette: secondaryPalette,
+		Tone: func(s *Scheme) float64 {
+			if isMonochrome(s) {
+				return 80
+			}
+			return 90
+		},
+		IsBackground:  true,
+		Background:    highestSurface,
+		ContrastCurve: &ContrastCurve{1, 1, 3, 4.5},
+		ToneDeltaPair: func(s *Scheme) *ToneDeltaPair {
+			return &ToneDeltaPair{SecondaryFixedDynamicColor, SecondaryFixedDimDynamicColor, 10, TonePolarityLighter, true}
+		},
+	}
+	SecondaryFixedDimDynamicColor = &DynamicColor{
+		Name:    "secondaryFixedDim",
+		Palette: secondaryPalette,
+		Tone: func(s *Scheme) float64 {
+			if isMonochrome(s) {
+				return 70
+			}
+			return 80
+		},
+		IsBackground:  true,
+		Background:    highestSurface,
+		ContrastCurve: &ContrastCurve{1, 1, 3, 4.5},
+		ToneDeltaPair: func(s *Scheme) *ToneDeltaPair {
+			return &ToneDeltaPair{SecondaryFixedDynamicColor, SecondaryFixedDimDynamicColor, 10, TonePolarityLighter, true}
+		},
+	}
+	OnSecondaryFixedDynamicColor = &DynamicColor{
+		Name:             "onSecondaryFixed",
+		Palette:          secondaryPalette,
+		Tone:             func(s *Scheme) float64 { return 10 },
+		Background:       func(s *Scheme) *DynamicColor { return SecondaryFixedDimDynamicColor },
+		SecondBackground: func(s *Scheme) *DynamicColor { return SecondaryFixedDynamicColor },
+		ContrastCurve:    &ContrastCurve{4.5, 7, 11, 21},
+	}
+	OnSecondaryFixedVariantDynamicColor = &DynamicColor{
+		Name:    "onSecondaryFixedVariant",
+		Palette: secondaryPalette,
+		Tone: func(s *Scheme) float64 {
+			if isMonochrome(s) {
+				return 25
+			}
+			return 30
+		},
+		Background:       func(s *Scheme) *DynamicColor { return SecondaryFixedDimDynamicColor },
+		SecondBackground: func(s *Scheme) *DynamicColor { return SecondaryFixedDynamicColor },
+		ContrastCurve:    &ContrastCurve{3, 4.5, 7, 11},
+	}
+
+	// ---------------------------------------------------------------------------------------------
+	// Tertiary roles
+	// ---------------------------------------------------------------------------------------------
+
+	TertiaryDynamicColor = &DynamicColor{
+		Name:    "tertiary",
+		Palette: tertiaryPalette,
+		Tone: func(s *Scheme) float64 {
+			if isMonochrome(s) {
+				return toneFor(s, 90, 25)
+			}
+			return toneFor(s, 80, 40)
+		},
+		IsBackground:  true,
+		Background:    highestSurface,
+		ContrastCurve: &ContrastCurve{3, 4.5, 7, 7},
+		ToneDeltaPair: func(s *Scheme) *ToneDeltaPair {
+			return &ToneDeltaPair{TertiaryContainerDynamicColor, TertiaryDynamicColor, 10, TonePolarityNearer, false}
+		},
+	}
+	OnTertiaryDynamicColor = &DynamicColor{
+		Name:    "onTertiary",
+		Palette: tertiaryPalette,
+		Tone: func(s *Scheme) float64 {
+			if isMonochrome(s) {
+				return toneFor(s, 10, 90)
+			}
+			return toneFor(s, 20, 100)
+		},
+		Background:    func(s *Scheme) *DynamicColor { return TertiaryDynamicColor },
+		ContrastCurve: &ContrastCurve{4.5, 7, 11, 21},
+	}
+	TertiaryContainerDynamicColor = &DynamicColor{
+		Name:    "tertiaryContainer",
+		Palette: tertiaryPalette,
+		Tone: func(s *Scheme) float64 {
+			if isMonochrome(s) {
+				return toneFor(s, 60, 49)
+			}
+			if isFidelity(s) {
+				proposed := s.tertiaryTone.Tone(int(math.Round(hctFromInt(s.sourceColor).tone)))
+				return fixIfDisliked(hctFromInt(proposed)).tone
+			}
+			return toneFor(s, 30, 90)
+		},
+		IsBackground:  true,
+		Background:    highestSurface,
+		ContrastCurve: &ContrastCurve{1, 1, 3, 4.5},
+		ToneDeltaPair: func(s *Scheme) *ToneDeltaPair {
+			return &ToneDeltaPair{TertiaryContainerDynamicColor, TertiaryDynamicColor, 10, TonePolarityNearer, false}
+		},
+	}
+	OnTertiaryContainerDynamicColor = &DynamicColor{
+		Name:    "onTertiaryContainer",
+		Palette: tertiaryPalette,
+		Tone: func(s *Scheme) float64 {
+			if isMonochrome(s) {
+				return toneFor(s, 0, 100)
+			}
+			if isFidelity(s) {
+				return foregroundTone(TertiaryContainerDynamicColor.Tone(s), 4.5)
+			}
+			return toneFor(s, 90, 10)
+		},
+		Background:    func(s *Scheme) *DynamicColor { return TertiaryContainerDynamicColor },
+		ContrastCurve: &ContrastCurve{4.5, 7, 11, 21},
+	}
+	TertiaryFixedDynamicColor = &DynamicColor{
+		Name:    "tertiaryFixed",
+		Palette: tertiaryPalette,
+		Tone: func(s *Scheme) float64 {
+			if isMonochrome(s) {
+				return 40
+			}
+			return 90
+		},
+		IsBackground:  true,
+		Background:    highestSurface,
+		ContrastCurve: &ContrastCurve{1, 1, 3, 4.5},
+		ToneDeltaPair: func(s *Scheme) *ToneDeltaPair {
+			return &ToneDeltaPair{TertiaryFixedDynamicColor, TertiaryFixedDimDynamicColor, 10, TonePolarityLighter, true}
+		},
+	}
+	TertiaryFixedDimDynamicColor = &DynamicColor{
+		Name:    "tertiaryFixedDim",
+		Palette: tertiaryPalette,
+		Tone: func(s *Scheme) float64 {
+			if isMonochrome(s) {
+				return 30
+			}
+			return 80
+		},
+		IsBackground:  true,
+		Background:    highestSurface,
+		ContrastCurve: &ContrastCurve{1, 1, 3, 4.5},
+		ToneDeltaPair: func(s *Scheme) *ToneDeltaPair {
+			return &ToneDeltaPair{TertiaryFixedDynamicColor, TertiaryFixedDimDynamicColor, 10, TonePolarityLighter, true}
+		},
+	}
+	OnTertiaryFixedDynamicColor = &DynamicColor{
+		Name:    "onTertiaryFixed",
+		Palette: tertiaryPalette,
+		Tone: func(s *Scheme) float64 {
+			if isMonochrome(s) {
+				return 100
+			}
+			return 10
+		},
+		Background:       func(s *Scheme) *DynamicColor { return TertiaryFixedDimDynamicColor },
+		SecondBackground: func(s *Scheme) *DynamicColor { return TertiaryFixedDynamicColor },
+		ContrastCurve:    &ContrastCurve{4.5, 7, 11, 21},
+	}
+	OnTertiaryFixedVariantDynamicColor = &DynamicColor{
+		Name:    "onTertiaryFixedVariant",
+		Palette: tertiaryPalette,
+		Tone: func(s *Scheme) float64 {
+			if isMonochrome(s) {
+				return 90
+			}
+			return 30
+		},
+		Background:       func(s *Scheme) *DynamicColor { return TertiaryFixedDimDynamicColor },
+		SecondBackground: func(s *Scheme) *DynamicColor { return TertiaryFixedDynamicColor },
+		ContrastCurve:    &ContrastCurve{3, 4.5, 7, 11},
+	}
+
+	// ---------------------------------------------------------------------------------------------
+	// Custom roles
+	// ---------------------------------------------------------------------------------------------
+
+	CustomDynamicColor = &DynamicColor{
+		Name:          "custom",
+		Palette:       customPalette,
+		Tone:          func(s *Scheme) float64 { return toneFor(s, 80, 40) },
+		IsBackground:  true,
+		Background:    highestSurface,
+		ContrastCurve: &ContrastCurve{3, 4.5, 7, 7},
+		ToneDeltaPair: func(s *Scheme) *ToneDeltaPair {
+			return &ToneDeltaPair{CustomContainerDynamicColor, CustomDynamicColor, 10, TonePolarityNearer, false}
+		},
+	}
+	OnCustomDynamicColor = &DynamicColor{
+		Name:          "onCustom",
+		Palette:       customPalette,
+		Tone:          func(s *Scheme) float64 { return toneFor(s, 20, 100) },
+		Background:    func(s *Scheme) *DynamicColor { return CustomDynamicColor },
+		ContrastCurve: &ContrastCurve{4.5, 7, 11, 21},
+	}
+	CustomContainerDynamicColor = &DynamicColor{
+		Name:          "customContainer",
+		Palette:       customPalette,
+		Tone:          func(s *Scheme) float64 { return toneFor(s, 30, 90) },
+		IsBackground:  true,
+		Background:    highestSurface,
+		ContrastCurve: &ContrastCurve{1, 1, 3, 4.5},
+		ToneDeltaPair: func(s *Scheme) *ToneDeltaPair {
+			return &ToneDeltaPair{CustomContainerDynamicColor, CustomDynamicColor, 10, TonePolarityNearer, false}
+		},
+	}
+	OnCustomContainerDynamicColor = &DynamicColor{
+		Name:          "onCustomContainer",
+		Palette:       customPalette,
+		Tone:          func(s *Scheme) float64 { return toneFor(s, 90, 10) },
+		Background:    func(s *Scheme) *DynamicColor { return CustomContainerDynamicColor },
+		ContrastCurve: &ContrastCurve{4.5, 7, 11, 21},
+	}
+	CustomFixedDynamicColor = &DynamicColor{
+		Name:          "customFixed",
+		Palette:       customPalette,
+		Tone:          func(s *Scheme) float64 { return 90 },
+		IsBackground:  true,
+		Background:    highestSurface,
+		ContrastCurve: &ContrastCurve{1, 1, 3, 4.5},
+		ToneDeltaPair: func(s *Scheme) *ToneDeltaPair {
+			return &ToneDeltaPair{CustomFixedDynamicColor, CustomFixedDimDynamicColor, 10, TonePolarityLighter, true}
+		},
+	}
+	CustomFixedDimDynamicColor = &DynamicColor{
+		Name:          "customFixedDim",
+		Palette:       customPalette,
+		Tone:          func(s *Scheme) float64 { return 80 },
+		IsBackground:  true,
+		Background:    highestSurface,
+		ContrastCurve: &ContrastCurve{1, 1, 3, 4.5},
+		ToneDeltaPair: func(s *Scheme) *ToneDeltaPair {
+			return &ToneDeltaPair{CustomFixedDynamicColor, CustomFixedDimDynamicColor, 10, TonePolarityLighter, true}
+		},
+	}
+	OnCustomFixedDynamicColor = &DynamicColor{
+		Name:             "onCustomFixed",
+		Palette:          customPalette,
+		Tone:             func(s *Scheme) float64 { return 10 },
+		Background:       func(s *Scheme) *DynamicColor { return CustomFixedDimDynamicColor },
+		SecondBackground: func(s *Scheme) *DynamicColor { return CustomFixedDynamicColor },
+		ContrastCurve:    &ContrastCurve{4.5, 7, 11, 21},
+	}
+	OnCustomFixedVariantDynamicColor = &DynamicColor{
+		Name:             "onCustomFixedVariant",
+		Palette:          customPalette,
+		Tone:             func(s *Scheme) float64 { return 30 },
+		Background:       func(s *Scheme) *DynamicColor { return CustomFixedDimDynamicColor },
+		SecondBackground: func(s *Scheme) *DynamicColor { return CustomFixedDynamicColor },
+		ContrastCurve:    &ContrastCurve{3, 4.5, 7, 11},
+	}
+
+	// ---------------------------------------------------------------------------------------------
+	// Error roles
+	// ---------------------------------------------------------------------------------------------
+
+	ErrorDynamicColor = &DynamicColor{
+		Name:          "error",
+		Palette:       errorPalette,
+		Tone:          func(s *Scheme) float64 { return toneFor(s, 80, 40) },
+		IsBackground:  true,
+		Background:    highestSurface,
+		ContrastCurve: &ContrastCurve{3, 4.5, 7, 7},
+		ToneDeltaPair: func(s *Scheme) *ToneDeltaPair {
+			return &ToneDeltaPair{ErrorContainerDynamicColor, ErrorDynamicColor, 10, TonePolarityNearer, false}
+		},
+	}
+	OnErrorDynamicColor = &DynamicColor{
+		Name:          "onError",
+		Palette:       errorPalette,
+		Tone:          func(s *Scheme) float64 { return toneFor(s, 20, 100) },
+		Background:    func(s *Scheme) *DynamicColor { return ErrorDynamicColor },
+		ContrastCurve: &ContrastCurve{4.5, 7, 11, 21},
+	}
+	ErrorContainerDynamicColor = &DynamicColor{
+		Name:          "errorContainer",
+		Palette:       errorPalette,
+		Tone:          func(s *Scheme) float64 { return toneFor(s, 30, 90) },
+		IsBackground:  true,
+		Background:    highestSurface,
+		ContrastCurve: &ContrastCurve{1, 1, 3, 4.5},
+		ToneDeltaPair: func(s *Scheme) *ToneDeltaPair {
+			return &ToneDeltaPair{ErrorContainerDynamicColor, ErrorDynamicColor, 10, TonePolarityNearer, false}
+		},
+	}
+	OnErrorContainerDynamicColor = &DynamicColor{
+		Name:          "onErrorContainer",
+		Palette:       errorPalette,
+		Tone:          func(s *Scheme) float64 { return toneFor(s, 90, 10) },
+		Background:    func(s *Scheme) *DynamicColor { return ErrorContainerDynamicColor },
+		ContrastCurve: &ContrastCurve{4.5, 7, 11, 21},
+	}
+}
+
+// highestSurface returns the surface accent colors must contrast with: the brightest
+// surface in dark schemes and the dimmest one in light schemes.
+func highestSurface(s *Scheme) *DynamicColor {
+	if s.isDark {
+		return SurfaceBrightDynamicColor
+	}
+	return SurfaceDimDynamicColor
+}
+
+// toneFor returns the tone matching the mode of the scheme.
+func toneFor(s *Scheme, dark float64, light float64) float64 {
+	if s.isDark {
+		return dark
+	}
+	return light
+}
+
+// isFidelity reports whether the scheme keeps the seed color in its containers.
+func isFidelity(s *Scheme) bool {
+	return s.variant == VariantFidelity || s.variant == VariantContent
+}
+
+// findDesiredChromaByTone returns the tone closest to the given one, moving toward darker tones
+// when byDecreasingTone is true and lighter ones otherwise, at which the hue reaches the chroma,
+// or the tone of its chroma peak on the way, as Material Color Utilities does.
+func findDesiredChromaByTone(hue float64, chroma float64, tone float64, byDecreasingTone bool) float64 {
+	answer := tone
+	closestToChroma := hctFrom(hue, chroma, tone)
+	if closestToChroma.chroma < chroma {
+		chromaPeak := closestToChroma.chroma
+		for closestToChroma.chroma < chroma && answer > 0 && answer < 100 {
+			if byDecreasingTone {
+				answer--
+			} else {
+				answer++
+			}
+			potentialSolution := hctFrom(hue, chroma, answer)
+			if chromaPeak > potentialSolution.chroma {
+				break
+			}
+			if math.Abs(potentialSolution.chroma-chroma) < 0.4 {
+				break
+			}
+			if math.Abs(potentialSolution.chroma-chroma) < math.Abs(closestToChroma.chroma-chroma) {
+				closestToChroma = potentialSolution
+			}
+			chromaPeak = math.Max(chromaPeak, potentialSolution.chroma)
+		}
+	}
+	return answer
+}
+
+// isMonochrome reports whether the scheme is grayscale.
+func isMonochrome(s *Scheme) bool {
+	return s.variant == VariantMonochrome
+}
+
+func primaryPalette(s *Scheme) *palettes.TonalPalette        { return s.primaryTone }
+func secondaryPalette(s *Scheme) *palettes.TonalPalette      { return s.secondaryTone }
+func tertiaryPalette(s *Scheme) *palettes.TonalPalette       { return s.tertiaryTone }
+func customPalette(s *Scheme) *palettes.TonalPalette         { return s.customTone }
+func neutralPalette(s *Scheme) *palettes.TonalPalette        { return s.neutralTone }
+func neutralVariantPalette(s *Scheme) *palettes.TonalPalette { return s.neutralVariantTone }
+func errorPalette(s *Scheme) *palettes.TonalPalette          { return s.errorTone }
@@ -0,0 +1,197 @@
+// Copyright (c) 2023 https://github.com/gio-eui
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR a PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+//
+// SPDX-License-Identifier: MIT
+
+package scheme
+
+import (
+	"image/color"
+	"strings"
+
+	"github.com/gio-eui/md3-colors/palettes"
+)
+
+// ExtendedColor is a named color added to a scheme, such as "success" or "warning", with the
+// four roles of a color group. Its roles are derived from its tonal palette like the custom roles.
+type ExtendedColor struct {
+	Name string
+
+	Color            color.NRGBA
+	OnColor          color.NRGBA
+	ColorContainer   color.NRGBA
+	OnColorContainer color.NRGBA
+
+	source    *palettes.TonalPalette
+	palette   *palettes.TonalPalette
+	harmonize bool
+}
+
+// extendedRolePrefix is the prefix of the roles of the extended colors.
+const extendedRolePrefix = "extended."
+
+// ExtendedRole returns the role of the extended color with the given name, where role is one of
+// "color", "onColor", "colorContainer" and "onColorContainer", such as "extended.success.onColor".
+// Color and SetColor accept the roles of the extended colors of a scheme.
+func ExtendedRole(name string, role string) Role {
+	return Role(extendedRolePrefix + name + "." + role)
+}
+
+// Extended returns the name of the extended color and the role within it of a role returned
+// by ExtendedRole, and false for the other roles.
+func (r Role) Extended() (name string, role string, ok bool) {
+	rest, ok := strings.CutPrefix(string(r), extendedRolePrefix)
+	if !ok {
+		return "", "", false
+	}
+	i := strings.LastIndexByte(rest, '.')
+	if i < 0 {
+		return "", "", false
+	}
+	return rest[:i], rest[i+1:], true
+}
+
+// Roles returns the roles of the extended color, in the order Color, OnColor, ColorContainer,
+// OnColorContainer.
+func (e ExtendedColor) Roles() []Role {
+	return []Role{
+		ExtendedRole(e.Name, "color"),
+		ExtendedRole(e.Name, "onColor"),
+		ExtendedRole(e.Name, "colorContainer"),
+		ExtendedRole(e.Name, "onColorContainer"),
+	}
+}
+
+// TonalPalette returns the tonal palette the roles of the extended color are derived from,
+// after its harmonization.
+func (e ExtendedColor) TonalPalette() *palettes.TonalPalette {
+	return e.palette
+}
+
+// Harmonized reports whether the extended color is harmonized with the primary tonal palette.
+func (e ExtendedColor) Harmonized() bool {
+	return e.harmonize
+}
+
+// WithExtendedColor returns a copy of the scheme with the extended color with the given name and
+// tonal palette, which replaces the one of the scheme with that name, if any. When harmonize is
+// true, the tonal palette is harmonized with the primary tonal palette of the scheme. The roles
+// are derived for the light or dark mode of the scheme.
+func (s *Scheme) WithExtendedColor(name string, tp *palettes.TonalPalette, harmonize bool) *Scheme {
+	c := s.Clone()
+	e := c.extendedColor(name, tp, harmonize)
+	replaced := false
+	for i := range c.extended {
+		if c.extended[i].Name == name {
+			c.extended[i] = e
+			replaced = true
+			break
+		}
+	}
+	if !replaced {
+		c.extended = append(c.extended, e)
+	}
+	c.updateRepairs()
+	return c
+}
+
+// WithoutExtendedColor returns a copy of the scheme without the extended color with the given name.
+func (s *Scheme) WithoutExtendedColor(name string) *Scheme {
+	c := s.Clone()
+	for i := range c.extended {
+		if c.extended[i].Name == name {
+			c.extended = append(c.extended[:i:i], c.extended[i+1:]...)
+			break
+		}
+	}
+	return c
+}
+
+// ExtendedColor returns the extended color of the scheme with the given name.
+func (s *Scheme) ExtendedColor(name string) (ExtendedColor, bool) {
+	for _, e := range s.extended {
+		if e.Name == name {
+			return e, true
+		}
+	}
+	return ExtendedColor{}, false
+}
+
+// ExtendedColors returns the extended colors of the scheme, in the order they were added.
+func (s *Scheme) ExtendedColors() []ExtendedColor {
+	return append([]ExtendedColor(nil), s.extended...)
+}
+
+// extendedField returns the field of the extended color holding the color of a role returned by
+// ExtendedRole, or nil if the scheme has no such extended color or the role is unknown.
+func (s *Scheme) extendedField(role Role) *color.NRGBA {
+	name, r, ok := role.Extended()
+	if !ok {
+		return nil
+	}
+	for i := range s.extended {
+		if s.extended[i].Name != name {
+			continue
+		}
+		e := &s.extended[i]
+		switch r {
+		case "color":
+			return &e.Color
+		case "onColor":
+			return &e.OnColor
+		case "colorContainer":
+			return &e.ColorContainer
+		case "onColorContainer":
+			return &e.OnColorContainer
+		}
+		return nil
+	}
+	return nil
+}
+
+// updateExtendedColors recomputes the roles of every extended color of the scheme, in place.
+func (s *Scheme) updateExtendedColors() {
+	extended := make([]ExtendedColor, len(s.extended))
+	for i, e := range s.extended {
+		if e.source == nil {
+			// Colors set without a tonal palette are kept as is
+			extended[i] = e
+			continue
+		}
+		extended[i] = s.extendedColor(e.Name, e.source, e.harmonize)
+	}
+	s.extended = extended
+}
+
+// extendedColor computes the roles of an extended color, using the custom dynamic colors on a
+// copy of the scheme whose custom palette is the palette of the extended color.
+func (s *Scheme) extendedColor(name string, tp *palettes.TonalPalette, harmonize bool) ExtendedColor {
+	tmp := *s
+	tmp.customTone = s.harmonized(tp, harmonize)
+	return ExtendedColor{
+		Name:             name,
+		Color:            s.nrgba(CustomDynamicColor.GetArgb(&tmp)),
+		OnColor:          s.nrgba(OnCustomDynamicColor.GetArgb(&tmp)),
+		ColorContainer:   s.nrgba(CustomContainerDynamicColor.GetArgb(&tmp)),
+		OnColorContainer: s.nrgba(OnCustomContainerDynamicColor.GetArgb(&tmp)),
+		source:           tp,
+		palette:          tmp.customTone,
+		harmonize:        harmonize,
+	}
+}
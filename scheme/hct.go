@@ -0,0 +1,487 @@
+// Copyright (c) 2023 https://github.com/gio-eui
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR a PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+//
+// SPDX-License-Identifier: MIT
+
+package scheme
+
+import "math"
+
+// hct is a color in the HCT color space: hue and chroma from CAM16, tone from L*.
+//
+// The tonal palettes of the schemes come from md3-colors, but deriving them from a seed,
+// solving the contrast of the roles, harmonizing and quantizing colors work on hue, chroma
+// and tone directly. These conversions are kept private to the package so that its API
+// does not depend on the color types of md3-colors. They follow the solver of Material Color
+// Utilities, like md3-colors, and hct_test.go checks that both agree.
+type hct struct {
+	hue    float64
+	chroma float64
+	tone   float64
+	argb   int
+}
+
+// hctFromInt converts an ARGB color to HCT.
+func hctFromInt(argb int) hct {
+	cam := cam16FromInt(argb)
+	return hct{hue: cam.hue, chroma: cam.chroma, tone: lstarFromArgb(argb), argb: argb}
+}
+
+// hctFrom creates the in-gamut HCT color closest to the given hue, chroma and tone.
+func hctFrom(hue float64, chroma float64, tone float64) hct {
+	return hctFromInt(hctToInt(hue, chroma, tone))
+}
+
+// toInt returns the ARGB value of the color.
+func (h hct) toInt() int {
+	return h.argb
+}
+
+// hctToInt returns the ARGB color with the given hue, chroma and tone.
+// When the color is out of the sRGB gamut, chroma is reduced until it fits.
+func hctToInt(hue float64, chroma float64, tone float64) int {
+	if chroma < 1 || math.Round(tone) <= 0 || math.Round(tone) >= 100 {
+		return argbFromLstar(tone)
+	}
+	hue = sanitizeDegrees(hue)
+	if argb, ok := findResultByJ(hue, chroma, yFromLstar(tone)); ok {
+		return argb
+	}
+
+	high := chroma
+	mid := chroma
+	low := 0.0
+	isFirstLoop := true
+	var answer *cam16
+	for math.Abs(low-high) >= 0.4 {
+		possibleAnswer := findCamByJ(hue, mid, tone)
+		if isFirstLoop {
+			if possibleAnswer != nil {
+				return possibleAnswer.toInt()
+			}
+			isFirstLoop = false
+			mid = low + (high-low)/2
+			continue
+		}
+		if possibleAnswer == nil {
+			high = mid
+		} else {
+			answer = possibleAnswer
+			low = mid
+		}
+		mid = low + (high-low)/2
+	}
+	if answer == nil {
+		return argbFromLstar(tone)
+	}
+	return answer.toInt()
+}
+
+// findResultByJ solves the CAM16 lightness of the color with the given hue, chroma and
+// luminance with Newton's method, as Material Color Utilities does, and returns false if
+// the color is out of the sRGB gamut.
+func findResultByJ(hue float64, chroma float64, y float64) (int, bool) {
+	j := math.Sqrt(y) * 11.0
+	for i := 0; i < 5; i++ {
+		r, g, b := cam16FromJch(j, chroma, hue).linrgb()
+		if r < 0 || g < 0 || b < 0 {
+			return 0, false
+		}
+		fnj := 0.2126*r + 0.7152*g + 0.0722*b
+		if fnj <= 0 {
+			return 0, false
+		}
+		if i == 4 || math.Abs(fnj-y) < 0.002 {
+			if r > 100.01 || g > 100.01 || b > 100.01 {
+				return 0, false
+			}
+			return argbFromRgb(delinearized(r), delinearized(g), delinearized(b)), true
+		}
+		j -= (fnj - y) * j / (2 * fnj)
+	}
+	return 0, false
+}
+
+// findCamByJ searches the CAM16 lightness that produces the given L* with the given hue
+// and chroma, and returns nil if no such color exists in the sRGB gamut.
+func findCamByJ(hue float64, chroma float64, tone float64) *cam16 {
+	low := 0.0
+	high := 100.0
+	bestdL := 1000.0
+	bestdE := 1000.0
+	var bestCam *cam16
+	for math.Abs(low-high) > 0.01 {
+		mid := low + (high-low)/2
+		clipped := cam16FromJch(mid, chroma, hue).toInt()
+		clippedLstar := lstarFromArgb(clipped)
+		dL := math.Abs(tone - clippedLstar)
+		if dL < 0.2 {
+			camClipped := cam16FromInt(clipped)
+			dE := camClipped.distance(cam16FromJch(camClipped.j, camClipped.chroma, hue))
+			if dE <= 1 && dE <= bestdE {
+				bestdL = dL
+				bestdE = dE
+				c := camClipped
+				bestCam = &c
+			}
+		}
+		if bestdL == 0 && bestdE == 0 {
+			break
+		}
+		if clippedLstar < tone {
+			low = mid
+		} else {
+			high = mid
+		}
+	}
+	return bestCam
+}
+
+// cam16 is a color in the CAM16 color appearance model, seen in the default viewing conditions.
+type cam16 struct {
+	hue    float64
+	chroma float64
+	j      float64
+	jstar  float64
+	astar  float64
+	bstar  float64
+}
+
+// viewingConditions holds the CAM16 parameters derived from the viewing environment.
+type viewingConditions struct {
+	n      float64
+	aw     float64
+	nbb    float64
+	ncb    float64
+	c      float64
+	nc     float64
+	rgbD   [3]float64
+	fl     float64
+	flRoot float64
+	z      float64
+}
+
+// defaultViewingConditions matches sRGB: D65 white point, a mid-gray background,
+// an average surround and the adapting luminance of a 200 lux environment.
+var defaultViewingConditions = newViewingConditions(
+	[3]float64{95.047, 100.0, 108.883},
+	200.0/math.Pi*yFromLstar(50.0)/100.0,
+	50.0,
+	2.0,
+	false,
+)
+
+// newViewingConditions computes the CAM16 parameters of a viewing environment.
+func newViewingConditions(whitePoint [3]float64, adaptingLuminance float64, backgroundLstar float64, surround float64, discountingIlluminant bool) viewingConditions {
+	rW := whitePoint[0]*0.401288 + whitePoint[1]*0.650173 + whitePoint[2]*-0.051461
+	gW := whitePoint[0]*-0.250268 + whitePoint[1]*1.204414 + whitePoint[2]*0.045854
+	bW := whitePoint[0]*-0.002079 + whitePoint[1]*0.048952 + whitePoint[2]*0.953127
+	f := 0.8 + surround/10.0
+	var c float64
+	if f >= 0.9 {
+		c = lerp(0.59, 0.69, (f-0.9)*10.0)
+	} else {
+		c = lerp(0.525, 0.59, (f-0.8)*10.0)
+	}
+	d := 1.0
+	if !discountingIlluminant {
+		d = f * (1.0 - (1.0/3.6)*math.Exp((-adaptingLuminance-42.0)/92.0))
+	}
+	d = clamp(0, 1, d)
+	rgbD := [3]float64{
+		d*(100.0/rW) + 1.0 - d,
+		d*(100.0/gW) + 1.0 - d,
+		d*(100.0/bW) + 1.0 - d,
+	}
+	k := 1.0 / (5.0*adaptingLuminance + 1.0)
+	k4 := k * k * k * k
+	k4F := 1.0 - k4
+	fl := k4*adaptingLuminance + 0.1*k4F*k4F*math.Cbrt(5.0*adaptingLuminance)
+	n := yFromLstar(backgroundLstar) / whitePoint[1]
+	z := 1.48 + math.Sqrt(n)
+	nbb := 0.725 / math.Pow(n, 0.2)
+	rgbAFactors := [3]float64{
+		math.Pow(fl*rgbD[0]*rW/100.0, 0.42),
+		math.Pow(fl*rgbD[1]*gW/100.0, 0.42),
+		math.Pow(fl*rgbD[2]*bW/100.0, 0.42),
+	}
+	var rgbA [3]float64
+	for i, factor := range rgbAFactors {
+		rgbA[i] = 400.0 * factor / (factor + 27.13)
+	}
+	aw := (2.0*rgbA[0] + rgbA[1] + 0.05*rgbA[2]) * nbb
+	return viewingConditions{
+		n:      n,
+		aw:     aw,
+		nbb:    nbb,
+		ncb:    nbb,
+		c:      c,
+		nc:     f,
+		rgbD:   rgbD,
+		fl:     fl,
+		flRoot: math.Pow(fl, 0.25),
+		z:      z,
+	}
+}
+
+// cam16FromInt converts an ARGB color to CAM16.
+func cam16FromInt(argb int) cam16 {
+	vc := defaultViewingConditions
+	red := linearized((argb >> 16) & 0xff)
+	green := linearized((argb >> 8) & 0xff)
+	blue := linearized(argb & 0xff)
+	x := 0.41233895*red + 0.35762064*green + 0.18051042*blue
+	y := 0.2126*red + 0.7152*green + 0.0722*blue
+	z := 0.01932141*red + 0.11916382*green + 0.95034478*blue
+
+	rC := 0.401288*x + 0.650173*y - 0.051461*z
+	gC := -0.250268*x + 1.204414*y + 0.045854*z
+	bC := -0.002079*x + 0.048952*y + 0.953127*z
+
+	rD := vc.rgbD[0] * rC
+	gD := vc.rgbD[1] * gC
+	bD := vc.rgbD[2] * bC
+
+	rAF := math.Pow(vc.fl*math.Abs(rD)/100.0, 0.42)
+	gAF := math.Pow(vc.fl*math.Abs(gD)/100.0, 0.42)
+	bAF := math.Pow(vc.fl*math.Abs(bD)/100.0, 0.42)
+	rA := signum(rD) * 400.0 * rAF / (rAF + 27.13)
+	gA := signum(gD) * 400.0 * gAF / (gAF + 27.13)
+	bA := signum(bD) * 400.0 * bAF / (bAF + 27.13)
+
+	a := (11.0*rA + -12.0*gA + bA) / 11.0
+	b := (rA + gA - 2.0*bA) / 9.0
+	u := (20.0*rA + 20.0*gA + 21.0*bA) / 20.0
+	p2 := (40.0*rA + 20.0*gA + bA) / 20.0
+
+	hue := sanitizeDegrees(math.Atan2(b, a) * 180.0 / math.Pi)
+	ac := p2 * vc.nbb
+	j := 100.0 * math.Pow(ac/vc.aw, vc.c*vc.z)
+
+	huePrime := hue
+	if hue < 20.14 {
+		huePrime = hue + 360
+	}
+	eHue := 0.25 * (math.Cos(huePrime*math.Pi/180.0+2.0) + 3.8)
+	p1 := 50000.0 / 13.0 * eHue * vc.nc * vc.ncb
+	t := p1 * math.Hypot(a, b) / (u + 0.305)
+	alpha := math.Pow(t, 0.9) * math.Pow(1.64-math.Pow(0.29, vc.n), 0.73)
+	chroma := alpha * math.Sqrt(j/100.0)
+	return newCam16(hue, chroma, j)
+}
+
+// cam16FromJch creates a CAM16 color from lightness, chroma and hue.
+func cam16FromJch(j float64, chroma float64, hue float64) cam16 {
+	return newCam16(hue, chroma, j)
+}
+
+// newCam16 fills the UCS coordinates of a CAM16 color.
+func newCam16(hue float64, chroma float64, j float64) cam16 {
+	m := chroma * defaultViewingConditions.flRoot
+	hueRadians := hue * math.Pi / 180.0
+	mstar := 1.0 / 0.0228 * math.Log1p(0.0228*m)
+	return cam16{
+		hue:    hue,
+		chroma: chroma,
+		j:      j,
+		jstar:  (1.0 + 100.0*0.007) * j / (1.0 + 0.007*j),
+		astar:  mstar * math.Cos(hueRadians),
+		bstar:  mstar * math.Sin(hueRadians),
+	}
+}
+
+// distance returns the CAM16-UCS color difference between two colors.
+func (c cam16) distance(other cam16) float64 {
+	dJ := c.jstar - other.jstar
+	dA := c.astar - other.astar
+	dB := c.bstar - other.bstar
+	dEPrime := math.Sqrt(dJ*dJ + dA*dA + dB*dB)
+	return 1.41 * math.Pow(dEPrime, 0.63)
+}
+
+// toInt converts the color to ARGB, clipping it to the sRGB gamut.
+func (c cam16) toInt() int {
+	r, g, b := c.linrgb()
+	return argbFromRgb(delinearized(r), delinearized(g), delinearized(b))
+}
+
+// linrgb converts the color to linear sRGB components in [0, 100], without clipping them.
+func (c cam16) linrgb() (float64, float64, float64) {
+	vc := defaultViewingConditions
+	alpha := 0.0
+	if c.chroma != 0 && c.j != 0 {
+		alpha = c.chroma / math.Sqrt(c.j/100.0)
+	}
+	t := math.Pow(alpha/math.Pow(1.64-math.Pow(0.29, vc.n), 0.73), 1.0/0.9)
+	hRad := c.hue * math.Pi / 180.0
+
+	eHue := 0.25 * (math.Cos(hRad+2.0) + 3.8)
+	ac := vc.aw * math.Pow(c.j/100.0, 1.0/vc.c/vc.z)
+	p1 := eHue * (50000.0 / 13.0) * vc.nc * vc.ncb
+	p2 := ac / vc.nbb
+
+	hSin := math.Sin(hRad)
+	hCos := math.Cos(hRad)
+
+	gamma := 23.0 * (p2 + 0.305) * t / (23.0*p1 + 11.0*t*hCos + 108.0*t*hSin)
+	a := gamma * hCos
+	b := gamma * hSin
+	rA := (460.0*p2 + 451.0*a + 288.0*b) / 1403.0
+	gA := (460.0*p2 - 891.0*a - 261.0*b) / 1403.0
+	bA := (460.0*p2 - 220.0*a - 6300.0*b) / 1403.0
+
+	rC := signum(rA) * (100.0 / vc.fl) * math.Pow(math.Max(0, 27.13*math.Abs(rA)/(400.0-math.Abs(rA))), 1.0/0.42)
+	gC := signum(gA) * (100.0 / vc.fl) * math.Pow(math.Max(0, 27.13*math.Abs(gA)/(400.0-math.Abs(gA))), 1.0/0.42)
+	bC := signum(bA) * (100.0 / vc.fl) * math.Pow(math.Max(0, 27.13*math.Abs(bA)/(400.0-math.Abs(bA))), 1.0/0.42)
+	rF := rC / vc.rgbD[0]
+	gF := gC / vc.rgbD[1]
+	bF := bC / vc.rgbD[2]
+
+	x := 1.86206786*rF - 1.01125463*gF + 0.14918677*bF
+	y := 0.38752654*rF + 0.62144744*gF - 0.00897398*bF
+	z := -0.01584150*rF - 0.03412294*gF + 1.04996444*bF
+	return linrgbFromXyz(x, y, z)
+}
+
+// argbFromXyz converts a color from the XYZ color space to ARGB.
+func argbFromXyz(x float64, y float64, z float64) int {
+	r, g, b := linrgbFromXyz(x, y, z)
+	return argbFromRgb(delinearized(r), delinearized(g), delinearized(b))
+}
+
+// linrgbFromXyz converts a color from the XYZ color space to linear sRGB.
+func linrgbFromXyz(x float64, y float64, z float64) (r float64, g float64, b float64) {
+	r = 3.2413774792388685*x - 1.5376652402851851*y - 0.49885366846268053*z
+	g = -0.9691452513005321*x + 1.8758853451067872*y + 0.04156585616912061*z
+	b = 0.05562093689691305*x - 0.20395524564742123*y + 1.0571799111220335*z
+	return r, g, b
+}
+
+// argbFromRgb packs opaque RGB components into an ARGB color.
+func argbFromRgb(r int, g int, b int) int {
+	return 0xff<<24 | (r&0xff)<<16 | (g&0xff)<<8 | b&0xff
+}
+
+// argbFromLstar returns the gray with the given L*.
+func argbFromLstar(lstar float64) int {
+	component := delinearized(yFromLstar(lstar))
+	return argbFromRgb(component, component, component)
+}
+
+// lstarFromArgb returns the L* of an ARGB color.
+func lstarFromArgb(argb int) float64 {
+	y := 0.2126*linearized((argb>>16)&0xff) + 0.7152*linearized((argb>>8)&0xff) + 0.0722*linearized(argb&0xff)
+	return lstarFromY(y)
+}
+
+// labFromArgb converts an ARGB color to CIE L*a*b*.
+func labFromArgb(argb int) (l float64, a float64, b float64) {
+	red := linearized((argb >> 16) & 0xff)
+	green := linearized((argb >> 8) & 0xff)
+	blue := linearized(argb & 0xff)
+	fx := labF((0.41233895*red + 0.35762064*green + 0.18051042*blue) / 95.047)
+	fy := labF((0.2126*red + 0.7152*green + 0.0722*blue) / 100.0)
+	fz := labF((0.01932141*red + 0.11916382*green + 0.95034478*blue) / 108.883)
+	return 116.0*fy - 16.0, 500.0 * (fx - fy), 200.0 * (fy - fz)
+}
+
+// argbFromLab converts a CIE L*a*b* color to ARGB.
+func argbFromLab(l float64, a float64, b float64) int {
+	fy := (l + 16.0) / 116.0
+	fx := a/500.0 + fy
+	fz := fy - b/200.0
+	return argbFromXyz(labInvf(fx)*95.047, labInvf(fy)*100.0, labInvf(fz)*108.883)
+}
+
+// yFromLstar converts an L* value to a relative luminance in [0, 100].
+func yFromLstar(lstar float64) float64 {
+	return 100.0 * labInvf((lstar+16.0)/116.0)
+}
+
+// lstarFromY converts a relative luminance in [0, 100] to an L* value.
+func lstarFromY(y float64) float64 {
+	return labF(y/100.0)*116.0 - 16.0
+}
+
+// linearized converts an 8-bit sRGB component to a linear component in [0, 100].
+func linearized(component int) float64 {
+	normalized := float64(component) / 255.0
+	if normalized <= 0.040449936 {
+		return normalized / 12.92 * 100.0
+	}
+	return math.Pow((normalized+0.055)/1.055, 2.4) * 100.0
+}
+
+// delinearized converts a linear component in [0, 100] to an 8-bit sRGB component.
+func delinearized(component float64) int {
+	normalized := component / 100.0
+	var delinearized float64
+	if normalized <= 0.0031308 {
+		delinearized = normalized * 12.92
+	} else {
+		delinearized = 1.055*math.Pow(normalized, 1.0/2.4) - 0.055
+	}
+	return int(clamp(0, 255, math.Round(delinearized*255.0)))
+}
+
+func labF(t float64) float64 {
+	const e = 216.0 / 24389.0
+	const kappa = 24389.0 / 27.0
+	if t > e {
+		return math.Cbrt(t)
+	}
+	return (kappa*t + 16) / 116
+}
+
+func labInvf(ft float64) float64 {
+	const e = 216.0 / 24389.0
+	const kappa = 24389.0 / 27.0
+	ft3 := ft * ft * ft
+	if ft3 > e {
+		return ft3
+	}
+	return (116*ft - 16) / kappa
+}
+
+// sanitizeDegrees wraps an angle in degrees to [0, 360).
+func sanitizeDegrees(degrees float64) float64 {
+	degrees = math.Mod(degrees, 360.0)
+	if degrees < 0 {
+		degrees += 360.0
+	}
+	return degrees
+}
+
+func signum(x float64) float64 {
+	switch {
+	case x < 0:
+		return -1
+	case x > 0:
+		return 1
+	}
+	return 0
+}
+
+func lerp(start float64, stop float64, amount float64) float64 {
+	return (1.0-amount)*start + amount*stop
+}
+
+func clamp(low float64, high float64, x float64) float64 {
+	return math.Min(math.Max(x, low), high)
+}
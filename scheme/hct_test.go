@@ -0,0 +1,53 @@
+// Copyright (c) 2023 https://github.com/gio-eui
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR a PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+//
+// SPDX-License-Identifier: MIT
+
+package scheme
+
+import (
+	"testing"
+
+	"github.com/gio-eui/md3-colors/palettes"
+)
+
+func TestHctMatchesTonalPalettes(t *testing.T) {
+	seeds := []int{0xff0000ff, 0xff4285f4, 0xff6750a4, 0xffb3261e, 0xff00ff00, 0xffffeb3b, 0xff795548, 0xff9e9e9e}
+	for _, seed := range seeds {
+		tp := palettes.NewTonalPaletteFromInt(seed)
+		h := hctFromInt(seed)
+		for tone := 0; tone <= 100; tone += 5 {
+			got := NRGBA(hctToInt(h.hue, h.chroma, float64(tone)))
+			want := NRGBA(tp.Tone(tone))
+			if d := colorDistance(got, want); d > 1 {
+				t.Errorf("seed %#x, tone %d: got %s, want %s from md3-colors (distance %.2f)",
+					seed, tone, Hex(got), Hex(want), d)
+			}
+		}
+	}
+}
+
+func TestHctRoundTrip(t *testing.T) {
+	for _, argb := range []int{0xff000000, 0xffffffff, 0xffff0000, 0xff00ff00, 0xff0000ff, 0xff6750a4, 0xff808080} {
+		h := hctFromInt(argb)
+		if got := hctToInt(h.hue, h.chroma, h.tone); got != argb {
+			t.Errorf("%#x: got %#x after a round trip through HCT", argb, got)
+		}
+	}
+}
@@ -0,0 +1,283 @@
+// Copyright (c) 2023 https://github.com/gio-eui
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR a PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+//
+// SPDX-License-Identifier: MIT
+
+package scheme
+
+import (
+	"encoding/json"
+	"fmt"
+	"image/color"
+	"strconv"
+	"strings"
+
+	"github.com/gio-eui/md3-colors/palettes"
+)
+
+// schemeJSON is the JSON representation of a scheme.
+type schemeJSON struct {
+	Dark            bool              `json:"dark"`
+	ContrastLevel   float64           `json:"contrastLevel"`
+	Variant         string            `json:"variant"`
+	Seed            string            `json:"seed,omitempty"`
+	KeyColors       map[string]string `json:"keyColors,omitempty"`
+	HarmonizeCustom bool              `json:"harmonizeCustom,omitempty"`
+	HarmonizeError  bool              `json:"harmonizeError,omitempty"`
+	Colors          map[Role]string   `json:"colors"`
+	Extended        []extendedJSON    `json:"extendedColors,omitempty"`
+	RepairContrast  bool              `json:"repairContrast,omitempty"`
+}
+
+// extendedJSON is the JSON representation of an extended color.
+type extendedJSON struct {
+	Name             string `json:"name"`
+	KeyColor         string `json:"keyColor,omitempty"`
+	Harmonize        bool   `json:"harmonize,omitempty"`
+	Color            string `json:"color"`
+	OnColor          string `json:"onColor"`
+	ColorContainer   string `json:"colorContainer"`
+	OnColorContainer string `json:"onColorContainer"`
+}
+
+// MarshalJSON encodes the scheme as JSON. Colors are keyed by their MD3 role name and
+// formatted as "#RRGGBB", or "#RRGGBBAA" when not opaque. The key colors of the tonal
+// palettes are recorded as well, so that the scheme can be regenerated once decoded, with the
+// custom and error key colors as set, before their harmonization.
+func (s *Scheme) MarshalJSON() ([]byte, error) {
+	v := schemeJSON{
+		Dark:            s.isDark,
+		ContrastLevel:   s.contrastLevel,
+		Variant:         s.variant.String(),
+		KeyColors:       make(map[string]string),
+		HarmonizeCustom: s.harmonizeCustom,
+		HarmonizeError:  s.harmonizeError,
+		Colors:          make(map[Role]string, len(roles)),
+		RepairContrast:  s.repairContrast,
+	}
+	if s.sourceColor != 0 {
+		v.Seed = Hex(s.nrgba(s.sourceColor))
+	}
+	keyPalettes := s.TonalPalettes()
+	keyPalettes["custom"] = s.customSource
+	keyPalettes["error"] = s.errorSource
+	for name, tp := range keyPalettes {
+		if tp != nil {
+			v.KeyColors[name] = Hex(s.nrgba(tp.GetKeyColor().ToInt()))
+		}
+	}
+	for _, entry := range roles {
+		v.Colors[entry.role] = Hex(*entry.field(s))
+	}
+	for _, e := range s.extended {
+		ej := extendedJSON{
+			Name:             e.Name,
+			Harmonize:        e.harmonize,
+			Color:            Hex(e.Color),
+			OnColor:          Hex(e.OnColor),
+			ColorContainer:   Hex(e.ColorContainer),
+			OnColorContainer: Hex(e.OnColorContainer),
+		}
+		if e.source != nil {
+			ej.KeyColor = Hex(s.nrgba(e.source.GetKeyColor().ToInt()))
+		}
+		v.Extended = append(v.Extended, ej)
+	}
+	return json.Marshal(v)
+}
+
+// UnmarshalJSON decodes a scheme encoded by MarshalJSON. The tonal palettes are
+// regenerated from the key colors, then every color listed is set as is.
+// Unknown key colors and roles are reported as errors.
+func (s *Scheme) UnmarshalJSON(data []byte) error {
+	var v schemeJSON
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+
+	decoded := &Scheme{
+		isDark:          v.Dark,
+		contrastLevel:   v.ContrastLevel,
+		harmonizeCustom: v.HarmonizeCustom,
+		harmonizeError:  v.HarmonizeError,
+	}
+	if v.Variant != "" {
+		variant, err := ParseVariant(v.Variant)
+		if err != nil {
+			return err
+		}
+		decoded.variant = variant
+	}
+	if v.Seed != "" {
+		seed, err := ParseHex(v.Seed)
+		if err != nil {
+			return err
+		}
+		decoded.sourceColor = Argb(seed)
+	}
+	for name, hex := range v.KeyColors {
+		key, err := ParseHex(hex)
+		if err != nil {
+			return err
+		}
+		decoded, err = decoded.WithTonalPalette(name, palettes.NewTonalPaletteFromInt(Argb(key)), v.Dark)
+		if err != nil {
+			return err
+		}
+	}
+	for role, hex := range v.Colors {
+		c, err := ParseHex(hex)
+		if err != nil {
+			return err
+		}
+		if !decoded.SetColor(role, c) {
+			return fmt.Errorf("scheme: unknown color role %q", role)
+		}
+	}
+	for _, ej := range v.Extended {
+		e, err := ej.decode()
+		if err != nil {
+			return err
+		}
+		if e.source != nil {
+			// The colors are set as is, only the tonal palette is regenerated
+			e.palette = decoded.harmonized(e.source, e.harmonize)
+		}
+		decoded = decoded.WithoutExtendedColor(e.Name)
+		decoded.extended = append(decoded.extended, e)
+	}
+	// The colors are already repaired: only recomputed colors will be.
+	decoded.repairContrast = v.RepairContrast
+
+	*s = *decoded
+	return nil
+}
+
+// decode decodes an extended color. Its tonal palette, if any, is the one of its key color.
+func (ej extendedJSON) decode() (ExtendedColor, error) {
+	e := ExtendedColor{Name: ej.Name, harmonize: ej.Harmonize}
+	if ej.Name == "" {
+		return e, fmt.Errorf("scheme: extended color without a name")
+	}
+	if ej.KeyColor != "" {
+		key, err := ParseHex(ej.KeyColor)
+		if err != nil {
+			return e, err
+		}
+		e.source = palettes.NewTonalPaletteFromInt(Argb(key))
+	}
+	for _, field := range []struct {
+		hex string
+		c   *color.NRGBA
+	}{
+		{ej.Color, &e.Color},
+		{ej.OnColor, &e.OnColor},
+		{ej.ColorContainer, &e.ColorContainer},
+		{ej.OnColorContainer, &e.OnColorContainer},
+	} {
+		c, err := ParseHex(field.hex)
+		if err != nil {
+			return e, fmt.Errorf("scheme: extended color %q: %w", ej.Name, err)
+		}
+		*field.c = c
+	}
+	return e, nil
+}
+
+// TonalPalettes returns the tonal palettes of the scheme, keyed by their MD3 name:
+// "primary", "secondary", "tertiary", "custom", "neutral", "neutralVariant" and "error".
+// Palettes that are not set are nil.
+func (s *Scheme) TonalPalettes() map[string]*palettes.TonalPalette {
+	return map[string]*palettes.TonalPalette{
+		"primary":        s.primaryTone,
+		"secondary":      s.secondaryTone,
+		"tertiary":       s.tertiaryTone,
+		"custom":         s.customTone,
+		"neutral":        s.neutralTone,
+		"neutralVariant": s.neutralVariantTone,
+		"error":          s.errorTone,
+	}
+}
+
+// WithTonalPalette sets the tonal palette with the given MD3 name, as listed by TonalPalettes,
+// and updates the colors derived from it.
+func (s *Scheme) WithTonalPalette(name string, tp *palettes.TonalPalette, isDark bool) (*Scheme, error) {
+	switch name {
+	case "primary":
+		return s.WithPrimaryTonalPalette(tp, isDark), nil
+	case "secondary":
+		return s.WithSecondaryTonalPalette(tp, isDark), nil
+	case "tertiary":
+		return s.WithTertiaryTonalPalette(tp, isDark), nil
+	case "custom":
+		return s.WithCustomTonalPalette(tp, isDark), nil
+	case "neutral":
+		return s.WithNeutralTonalPalette(tp, isDark), nil
+	case "neutralVariant":
+		return s.WithNeutralVariantTonalPalette(tp, isDark), nil
+	case "error":
+		return s.WithErrorTonalPalette(tp, isDark), nil
+	default:
+		return s, fmt.Errorf("scheme: unknown key color %q", name)
+	}
+}
+
+// Hex formats a color as "#RRGGBB", or "#RRGGBBAA" when it is not opaque.
+func Hex(c color.NRGBA) string {
+	if c.A == 0xff {
+		return fmt.Sprintf("#%02X%02X%02X", c.R, c.G, c.B)
+	}
+	return fmt.Sprintf("#%02X%02X%02X%02X", c.R, c.G, c.B, c.A)
+}
+
+// ParseHex parses a color formatted as "#RRGGBB" or "#RRGGBBAA".
+func ParseHex(hex string) (color.NRGBA, error) {
+	digits := strings.TrimPrefix(hex, "#")
+	if len(digits) != 6 && len(digits) != 8 {
+		return color.NRGBA{}, fmt.Errorf("scheme: invalid hex color %q", hex)
+	}
+	value, err := strconv.ParseUint(digits, 16, 32)
+	if err != nil {
+		return color.NRGBA{}, fmt.Errorf("scheme: invalid hex color %q", hex)
+	}
+	if len(digits) == 6 {
+		value = value<<8 | 0xff
+	}
+	return color.NRGBA{
+		R: uint8(value >> 24),
+		G: uint8(value >> 16),
+		B: uint8(value >> 8),
+		A: uint8(value),
+	}, nil
+}
+
+// Argb converts a color to an int representing an argb color, as taken by the scheme constructors.
+func Argb(c color.NRGBA) int {
+	return int(c.A)<<24 | int(c.R)<<16 | int(c.G)<<8 | int(c.B)
+}
+
+// NRGBA converts an ARGB color, such as 0xff6750a4, to a color.NRGBA.
+func NRGBA(argb int) color.NRGBA {
+	return color.NRGBA{
+		R: uint8(argb >> 16),
+		G: uint8(argb >> 8),
+		B: uint8(argb),
+		A: uint8(argb >> 24),
+	}
+}
@@ -0,0 +1,157 @@
+// Copyright (c) 2023 https://github.com/gio-eui
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR a PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+//
+// SPDX-License-Identifier: MIT
+
+package scheme
+
+import (
+	"bytes"
+	"encoding/json"
+	"image/color"
+	"strings"
+	"testing"
+
+	"github.com/gio-eui/md3-colors/palettes"
+)
+
+func TestSchemeJSONRoundTrip(t *testing.T) {
+	for _, isDark := range []bool{false, true} {
+		s := FromSeed(0xff6750a4, VariantVibrant, isDark).
+			WithCustomTonalPalette(palettes.NewTonalPaletteFromInt(0xff00897b), isDark).
+			WithExtendedColor("warning", palettes.NewTonalPaletteFromInt(0xffffb300), true).
+			WithContrastLevel(0.5)
+		data, err := json.Marshal(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var decoded Scheme
+		if err := json.Unmarshal(data, &decoded); err != nil {
+			t.Fatal(err)
+		}
+		for _, role := range Roles() {
+			want, _ := s.Color(role)
+			if got, _ := decoded.Color(role); got != want {
+				t.Errorf("dark=%t: %s is %s after a round trip, want %s", isDark, role, Hex(got), Hex(want))
+			}
+		}
+		if decoded.isDark != isDark || decoded.contrastLevel != 0.5 || decoded.variant != VariantVibrant {
+			t.Errorf("dark=%t: settings not kept: dark=%t, contrast=%v, variant=%v",
+				isDark, decoded.isDark, decoded.contrastLevel, decoded.variant)
+		}
+		if e, ok := decoded.ExtendedColor("warning"); !ok || !e.Harmonized() || e.TonalPalette() == nil {
+			t.Errorf("dark=%t: extended color not kept: %+v", isDark, e)
+		}
+		again, err := json.Marshal(&decoded)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(again, data) {
+			t.Errorf("dark=%t: encoding changed after a round trip:\n%s\n%s", isDark, data, again)
+		}
+	}
+}
+
+func TestSchemeJSONHarmonization(t *testing.T) {
+	custom := palettes.NewTonalPaletteFromInt(0xff00897b)
+	s := FromSeed(0xff6750a4, VariantTonalSpot, false).
+		WithCustomTonalPalette(custom, false).
+		WithHarmonization(true, true)
+	data, err := json.Marshal(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var v schemeJSON
+	if err := json.Unmarshal(data, &v); err != nil {
+		t.Fatal(err)
+	}
+	if want := Hex(s.nrgba(custom.GetKeyColor().ToInt())); v.KeyColors["custom"] != want {
+		t.Errorf("custom key color is %s, want the unharmonized %s", v.KeyColors["custom"], want)
+	}
+	if want := Hex(s.nrgba(ErrorTonalPalette.GetKeyColor().ToInt())); v.KeyColors["error"] != want {
+		t.Errorf("error key color is %s, want the unharmonized %s", v.KeyColors["error"], want)
+	}
+
+	var decoded Scheme
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if !decoded.harmonizeCustom || !decoded.harmonizeError {
+		t.Fatalf("harmonization not kept: custom=%t, error=%t", decoded.harmonizeCustom, decoded.harmonizeError)
+	}
+	// Harmonized palettes keep following the primary palette once decoded.
+	primary := palettes.NewTonalPaletteFromInt(0xff006c4c)
+	want := s.WithPrimaryTonalPalette(primary, false)
+	got := decoded.WithPrimaryTonalPalette(primary, false)
+	for _, role := range []Role{RoleCustom, RoleCustomContainer, RoleError, RoleErrorContainer} {
+		w, _ := want.Color(role)
+		if g, _ := got.Color(role); g != w {
+			t.Errorf("%s is %s after a new primary palette, want %s", role, Hex(g), Hex(w))
+		}
+	}
+}
+
+func TestSchemeUnmarshalJSONErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		err  string
+	}{
+		{"unknown role", `{"colors":{"primary":"#6750A4","notARole":"#FFFFFF"}}`, `unknown color role "notARole"`},
+		{"bad hex color", `{"colors":{"primary":"#6750A"}}`, `invalid hex color "#6750A"`},
+		{"bad hex digits", `{"colors":{"primary":"#GG50A4"}}`, `invalid hex color "#GG50A4"`},
+		{"unknown key color", `{"keyColors":{"quaternary":"#6750A4"}}`, `unknown key color "quaternary"`},
+		{"unknown variant", `{"variant":"pastel"}`, `pastel`},
+		{"extended color without a name", `{"extendedColors":[{"color":"#FFB300"}]}`, `without a name`},
+		{"not an object", `[]`, `cannot unmarshal`},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var s Scheme
+			err := json.Unmarshal([]byte(test.data), &s)
+			if err == nil || !strings.Contains(err.Error(), test.err) {
+				t.Errorf("got error %v, want an error containing %q", err, test.err)
+			}
+		})
+	}
+}
+
+func TestParseHex(t *testing.T) {
+	tests := []struct {
+		hex  string
+		want color.NRGBA
+		ok   bool
+	}{
+		{"#6750A4", color.NRGBA{0x67, 0x50, 0xa4, 0xff}, true},
+		{"6750a4", color.NRGBA{0x67, 0x50, 0xa4, 0xff}, true},
+		{"#6750A480", color.NRGBA{0x67, 0x50, 0xa4, 0x80}, true},
+		{"#675", color.NRGBA{}, false},
+		{"#6750AZ", color.NRGBA{}, false},
+		{"", color.NRGBA{}, false},
+	}
+	for _, test := range tests {
+		got, err := ParseHex(test.hex)
+		if (err == nil) != test.ok || got != test.want {
+			t.Errorf("ParseHex(%q) = %v, %v, want %v, ok=%t", test.hex, got, err, test.want, test.ok)
+		}
+		if test.ok && !strings.EqualFold(strings.TrimPrefix(Hex(got), "#"), strings.TrimPrefix(test.hex, "#")) {
+			t.Errorf("Hex(%v) = %q, want %q", got, Hex(got), test.hex)
+		}
+	}
+}
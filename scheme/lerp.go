@@ -0,0 +1,134 @@
+// Copyright (c) 2023 https://github.com/gio-eui
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR a PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+//
+// SPDX-License-Identifier: MIT
+
+package scheme
+
+import (
+	"image/color"
+	"math"
+)
+
+// Lerp returns a new scheme whose colors are interpolated between the colors of a and b, with t
+// going from 0 (a) to 1 (b). Colors are interpolated in the OKLab perceptual color space, and
+// extended colors are interpolated when both schemes have them. The new scheme has no tonal
+// palettes, so its colors are not regenerated by WithContrastLevel, except when t is 0 or 1,
+// where it is a copy of a or b.
+func Lerp(a *Scheme, b *Scheme, t float64) *Scheme {
+	switch {
+	case t <= 0:
+		return a.Clone()
+	case t >= 1:
+		return b.Clone()
+	}
+
+	s := a.colorsOnly()
+	for _, entry := range roles {
+		*entry.field(s) = LerpColor(*entry.field(a), *entry.field(b), t)
+	}
+	fa, fb := toneFields(a), toneFields(b)
+	for i, field := range toneFields(s) {
+		*field = LerpColor(*fa[i], *fb[i], t)
+	}
+	for i, e := range s.extended {
+		other, ok := b.ExtendedColor(e.Name)
+		if !ok {
+			continue
+		}
+		s.extended[i].Color = LerpColor(e.Color, other.Color, t)
+		s.extended[i].OnColor = LerpColor(e.OnColor, other.OnColor, t)
+		s.extended[i].ColorContainer = LerpColor(e.ColorContainer, other.ColorContainer, t)
+		s.extended[i].OnColorContainer = LerpColor(e.OnColorContainer, other.OnColorContainer, t)
+	}
+	if t >= 0.5 {
+		s.isDark = b.isDark
+		s.contrastLevel = b.contrastLevel
+	}
+	return s
+}
+
+// LerpColor interpolates between two colors in the OKLab perceptual color space, with t going
+// from 0 (a) to 1 (b). Alpha is interpolated linearly.
+func LerpColor(a color.NRGBA, b color.NRGBA, t float64) color.NRGBA {
+	l1, a1, b1 := oklabFromNRGBA(a)
+	l2, a2, b2 := oklabFromNRGBA(b)
+	c := nrgbaFromOklab(lerp(l1, l2, t), lerp(a1, a2, t), lerp(b1, b2, t))
+	c.A = uint8(math.Round(lerp(float64(a.A), float64(b.A), t)))
+	return c
+}
+
+// colorsOnly returns a copy of the scheme without its tonal palettes, keeping its colors.
+func (s *Scheme) colorsOnly() *Scheme {
+	c := s.Clone()
+	c.primaryTone = nil
+	c.secondaryTone = nil
+	c.tertiaryTone = nil
+	c.customTone = nil
+	c.neutralTone = nil
+	c.neutralVariantTone = nil
+	c.errorTone = nil
+	c.customSource = nil
+	c.errorSource = nil
+	for i := range c.extended {
+		c.extended[i].source = nil
+		c.extended[i].palette = nil
+	}
+	return c
+}
+
+// oklabFromNRGBA converts a color to OKLab.
+func oklabFromNRGBA(c color.NRGBA) (l float64, a float64, b float64) {
+	r := linearized(int(c.R)) / 100
+	g := linearized(int(c.G)) / 100
+	bl := linearized(int(c.B)) / 100
+
+	lms := [3]float64{
+		0.4122214708*r + 0.5363325363*g + 0.0514459929*bl,
+		0.2119034982*r + 0.6806995451*g + 0.1073969566*bl,
+		0.0883024619*r + 0.2817188376*g + 0.6299787005*bl,
+	}
+	for i := range lms {
+		lms[i] = math.Cbrt(lms[i])
+	}
+	return 0.2104542553*lms[0] + 0.7936177850*lms[1] - 0.0040720468*lms[2],
+		1.9779984951*lms[0] - 2.4285922050*lms[1] + 0.4505937099*lms[2],
+		0.0259040371*lms[0] + 0.7827717662*lms[1] - 0.8086757660*lms[2]
+}
+
+// nrgbaFromOklab converts an OKLab color to an opaque color, clamped to the sRGB gamut.
+func nrgbaFromOklab(l float64, a float64, b float64) color.NRGBA {
+	lms := [3]float64{
+		l + 0.3963377774*a + 0.2158037573*b,
+		l - 0.1055613458*a - 0.0638541728*b,
+		l - 0.0894841775*a - 1.2914855480*b,
+	}
+	for i := range lms {
+		lms[i] = lms[i] * lms[i] * lms[i]
+	}
+	r := 4.0767416621*lms[0] - 3.3077115913*lms[1] + 0.2309699292*lms[2]
+	g := -1.2684380046*lms[0] + 2.6097574011*lms[1] - 0.3413193965*lms[2]
+	bl := -0.0041960863*lms[0] - 0.7034186147*lms[1] + 1.7076147010*lms[2]
+	return color.NRGBA{
+		R: uint8(delinearized(r * 100)),
+		G: uint8(delinearized(g * 100)),
+		B: uint8(delinearized(bl * 100)),
+		A: 0xff,
+	}
+}
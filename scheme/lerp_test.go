@@ -0,0 +1,91 @@
+// Copyright (c) 2023 https://github.com/gio-eui
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR a PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+//
+// SPDX-License-Identifier: MIT
+
+package scheme
+
+import (
+	"image/color"
+	"testing"
+)
+
+func TestLerpEndpoints(t *testing.T) {
+	a := FromSeed(0xff6750a4, VariantTonalSpot, false)
+	b := FromSeed(0xff6750a4, VariantTonalSpot, true)
+	for _, tt := range []struct {
+		t    float64
+		want *Scheme
+	}{
+		{-1, a}, {0, a}, {1, b}, {2, b},
+	} {
+		s := Lerp(a, b, tt.t)
+		if s == tt.want {
+			t.Errorf("t=%v: Lerp returned a scheme itself", tt.t)
+		}
+		for _, role := range Roles() {
+			want, _ := tt.want.Color(role)
+			if got, _ := s.Color(role); got != want {
+				t.Errorf("t=%v: %s is %s, want %s", tt.t, role, Hex(got), Hex(want))
+			}
+		}
+	}
+}
+
+func TestLerpMidway(t *testing.T) {
+	a := FromSeed(0xff6750a4, VariantTonalSpot, false)
+	b := FromSeed(0xff6750a4, VariantTonalSpot, true)
+	s := Lerp(a, b, 0.5)
+	for _, role := range []Role{RoleSurface, RoleOnSurface, RolePrimary} {
+		ca, _ := a.Color(role)
+		cb, _ := b.Color(role)
+		c, _ := s.Color(role)
+		// Midway in OKLab is about as far from both ends in CIELAB.
+		da, db := colorDistance(c, ca), colorDistance(c, cb)
+		if da == 0 || db == 0 || da > 2*db || db > 2*da {
+			t.Errorf("%s: %s is ΔE %.1f from %s and %.1f from %s", role, Hex(c), da, Hex(ca), db, Hex(cb))
+		}
+	}
+	if s.TonalPalettes()["primary"] != nil {
+		t.Error("interpolated scheme has tonal palettes")
+	}
+	if !s.isDark {
+		t.Error("scheme midway is not dark")
+	}
+}
+
+func TestLerpColor(t *testing.T) {
+	black := color.NRGBA{A: 0xff}
+	white := color.NRGBA{R: 0xff, G: 0xff, B: 0xff, A: 0x00}
+	if got := LerpColor(black, white, 0); got != black {
+		t.Errorf("t=0: %v, want %v", got, black)
+	}
+	if got := LerpColor(black, white, 1); got != white {
+		t.Errorf("t=1: %v, want %v", got, white)
+	}
+	// Lightness grows and alpha shrinks with t.
+	prev := LerpColor(black, white, 0)
+	for i := 1; i <= 10; i++ {
+		c := LerpColor(black, white, float64(i)/10)
+		if c.R < prev.R || c.A > prev.A {
+			t.Errorf("t=%v: %v after %v", float64(i)/10, c, prev)
+		}
+		prev = c
+	}
+}
@@ -0,0 +1,468 @@
+// Copyright (c) 2023 https://github.com/gio-eui
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR a PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+//
+// SPDX-License-Identifier: MIT
+
+package scheme
+
+import (
+	"math"
+	"math/rand"
+)
+
+// quantizeCelebi reduces the pixels to at most maxColors colors, and returns the population
+// of each of them. Wu's quantizer gives the starting clusters of a weighted k-means in L*a*b*.
+func quantizeCelebi(pixels []int, maxColors int) map[int]int {
+	wu := newWuQuantizer()
+	return quantizeWsmeans(pixels, wu.quantize(pixels, maxColors), maxColors)
+}
+
+// ---------------------------------------------------------------------------------------------
+// Wu's quantizer
+// ---------------------------------------------------------------------------------------------
+
+const (
+	wuIndexBits  = 5
+	wuIndexCount = 33    // (1 << wuIndexBits) + 1
+	wuTotalSize  = 35937 // wuIndexCount * wuIndexCount * wuIndexCount
+)
+
+type wuDirection int
+
+const (
+	wuRed wuDirection = iota
+	wuGreen
+	wuBlue
+)
+
+// wuBox is a box of the RGB cube, with lower bounds exclusive and upper bounds inclusive.
+type wuBox struct {
+	r0, r1 int
+	g0, g1 int
+	b0, b1 int
+	vol    int
+}
+
+// wuQuantizer divides the RGB cube in boxes of minimal variance, using the cumulative
+// moments of the color histogram.
+type wuQuantizer struct {
+	weights  []float64
+	momentsR []float64
+	momentsG []float64
+	momentsB []float64
+	moments  []float64
+	cubes    []wuBox
+}
+
+func newWuQuantizer() *wuQuantizer {
+	return &wuQuantizer{
+		weights:  make([]float64, wuTotalSize),
+		momentsR: make([]float64, wuTotalSize),
+		momentsG: make([]float64, wuTotalSize),
+		momentsB: make([]float64, wuTotalSize),
+		moments:  make([]float64, wuTotalSize),
+	}
+}
+
+// quantize returns the mean colors of at most maxColors boxes.
+func (q *wuQuantizer) quantize(pixels []int, maxColors int) []int {
+	q.constructHistogram(pixels)
+	q.createMoments()
+	count := q.createBoxes(maxColors)
+
+	colors := make([]int, 0, count)
+	for _, cube := range q.cubes[:count] {
+		weight := q.volume(cube, q.weights)
+		if weight > 0 {
+			r := int(q.volume(cube, q.momentsR) / weight)
+			g := int(q.volume(cube, q.momentsG) / weight)
+			b := int(q.volume(cube, q.momentsB) / weight)
+			colors = append(colors, argbFromRgb(r, g, b))
+		}
+	}
+	return colors
+}
+
+func (q *wuQuantizer) constructHistogram(pixels []int) {
+	const bitsToRemove = 8 - wuIndexBits
+	for _, pixel := range pixels {
+		red := (pixel >> 16) & 0xff
+		green := (pixel >> 8) & 0xff
+		blue := pixel & 0xff
+		index := wuIndex((red>>bitsToRemove)+1, (green>>bitsToRemove)+1, (blue>>bitsToRemove)+1)
+		q.weights[index]++
+		q.momentsR[index] += float64(red)
+		q.momentsG[index] += float64(green)
+		q.momentsB[index] += float64(blue)
+		q.moments[index] += float64(red*red + green*green + blue*blue)
+	}
+}
+
+func (q *wuQuantizer) createMoments() {
+	for r := 1; r < wuIndexCount; r++ {
+		var area, areaR, areaG, areaB, area2 [wuIndexCount]float64
+		for g := 1; g < wuIndexCount; g++ {
+			var line, lineR, lineG, lineB, line2 float64
+			for b := 1; b < wuIndexCount; b++ {
+				index := wuIndex(r, g, b)
+				line += q.weights[index]
+				lineR += q.momentsR[index]
+				lineG += q.momentsG[index]
+				lineB += q.momentsB[index]
+				line2 += q.moments[index]
+
+				area[b] += line
+				areaR[b] += lineR
+				areaG[b] += lineG
+				areaB[b] += lineB
+				area2[b] += line2
+
+				previousIndex := wuIndex(r-1, g, b)
+				q.weights[index] = q.weights[previousIndex] + area[b]
+				q.momentsR[index] = q.momentsR[previousIndex] + areaR[b]
+				q.momentsG[index] = q.momentsG[previousIndex] + areaG[b]
+				q.momentsB[index] = q.momentsB[previousIndex] + areaB[b]
+				q.moments[index] = q.moments[previousIndex] + area2[b]
+			}
+		}
+	}
+}
+
+// createBoxes cuts the box of largest variance in two until there are maxColors boxes,
+// and returns the number of boxes created.
+func (q *wuQuantizer) createBoxes(maxColors int) int {
+	q.cubes = make([]wuBox, maxColors)
+	q.cubes[0] = wuBox{r1: wuIndexCount - 1, g1: wuIndexCount - 1, b1: wuIndexCount - 1}
+	volumeVariance := make([]float64, maxColors)
+
+	next := 0
+	for i := 1; i < maxColors; i++ {
+		if q.cut(&q.cubes[next], &q.cubes[i]) {
+			volumeVariance[next] = 0
+			if q.cubes[next].vol > 1 {
+				volumeVariance[next] = q.variance(q.cubes[next])
+			}
+			volumeVariance[i] = 0
+			if q.cubes[i].vol > 1 {
+				volumeVariance[i] = q.variance(q.cubes[i])
+			}
+		} else {
+			volumeVariance[next] = 0
+			i--
+		}
+
+		next = 0
+		temp := volumeVariance[0]
+		for j := 1; j <= i; j++ {
+			if volumeVariance[j] > temp {
+				temp = volumeVariance[j]
+				next = j
+			}
+		}
+		if temp <= 0 {
+			return i + 1
+		}
+	}
+	return maxColors
+}
+
+func (q *wuQuantizer) variance(cube wuBox) float64 {
+	dr := q.volume(cube, q.momentsR)
+	dg := q.volume(cube, q.momentsG)
+	db := q.volume(cube, q.momentsB)
+	xx := q.volume(cube, q.moments)
+	hypotenuse := dr*dr + dg*dg + db*db
+	return xx - hypotenuse/q.volume(cube, q.weights)
+}
+
+func (q *wuQuantizer) cut(one *wuBox, two *wuBox) bool {
+	wholeR := q.volume(*one, q.momentsR)
+	wholeG := q.volume(*one, q.momentsG)
+	wholeB := q.volume(*one, q.momentsB)
+	wholeW := q.volume(*one, q.weights)
+
+	cutR, maxR := q.maximize(*one, wuRed, one.r0+1, one.r1, wholeR, wholeG, wholeB, wholeW)
+	cutG, maxG := q.maximize(*one, wuGreen, one.g0+1, one.g1, wholeR, wholeG, wholeB, wholeW)
+	cutB, maxB := q.maximize(*one, wuBlue, one.b0+1, one.b1, wholeR, wholeG, wholeB, wholeW)
+
+	var direction wuDirection
+	switch {
+	case maxR >= maxG && maxR >= maxB:
+		if cutR < 0 {
+			return false
+		}
+		direction = wuRed
+	case maxG >= maxR && maxG >= maxB:
+		direction = wuGreen
+	default:
+		direction = wuBlue
+	}
+
+	two.r1 = one.r1
+	two.g1 = one.g1
+	two.b1 = one.b1
+	switch direction {
+	case wuRed:
+		one.r1 = cutR
+		two.r0, two.g0, two.b0 = one.r1, one.g0, one.b0
+	case wuGreen:
+		one.g1 = cutG
+		two.r0, two.g0, two.b0 = one.r0, one.g1, one.b0
+	case wuBlue:
+		one.b1 = cutB
+		two.r0, two.g0, two.b0 = one.r0, one.g0, one.b1
+	}
+	one.vol = (one.r1 - one.r0) * (one.g1 - one.g0) * (one.b1 - one.b0)
+	two.vol = (two.r1 - two.r0) * (two.g1 - two.g0) * (two.b1 - two.b0)
+	return true
+}
+
+// maximize finds where to cut the cube along the direction to minimize the variance
+// of the two halves, and returns -1 if the cube cannot be cut.
+func (q *wuQuantizer) maximize(cube wuBox, direction wuDirection, first int, last int, wholeR float64, wholeG float64, wholeB float64, wholeW float64) (int, float64) {
+	bottomR := q.bottom(cube, direction, q.momentsR)
+	bottomG := q.bottom(cube, direction, q.momentsG)
+	bottomB := q.bottom(cube, direction, q.momentsB)
+	bottomW := q.bottom(cube, direction, q.weights)
+
+	max := 0.0
+	cut := -1
+	for i := first; i < last; i++ {
+		halfR := bottomR + q.top(cube, direction, i, q.momentsR)
+		halfG := bottomG + q.top(cube, direction, i, q.momentsG)
+		halfB := bottomB + q.top(cube, direction, i, q.momentsB)
+		halfW := bottomW + q.top(cube, direction, i, q.weights)
+		if halfW == 0 {
+			continue
+		}
+		temp := (halfR*halfR + halfG*halfG + halfB*halfB) / halfW
+
+		halfR = wholeR - halfR
+		halfG = wholeG - halfG
+		halfB = wholeB - halfB
+		halfW = wholeW - halfW
+		if halfW == 0 {
+			continue
+		}
+		temp += (halfR*halfR + halfG*halfG + halfB*halfB) / halfW
+
+		if temp > max {
+			max = temp
+			cut = i
+		}
+	}
+	return cut, max
+}
+
+func (q *wuQuantizer) volume(cube wuBox, moment []float64) float64 {
+	return moment[wuIndex(cube.r1, cube.g1, cube.b1)] -
+		moment[wuIndex(cube.r1, cube.g1, cube.b0)] -
+		moment[wuIndex(cube.r1, cube.g0, cube.b1)] +
+		moment[wuIndex(cube.r1, cube.g0, cube.b0)] -
+		moment[wuIndex(cube.r0, cube.g1, cube.b1)] +
+		moment[wuIndex(cube.r0, cube.g1, cube.b0)] +
+		moment[wuIndex(cube.r0, cube.g0, cube.b1)] -
+		moment[wuIndex(cube.r0, cube.g0, cube.b0)]
+}
+
+func (q *wuQuantizer) bottom(cube wuBox, direction wuDirection, moment []float64) float64 {
+	switch direction {
+	case wuRed:
+		return -moment[wuIndex(cube.r0, cube.g1, cube.b1)] +
+			moment[wuIndex(cube.r0, cube.g1, cube.b0)] +
+			moment[wuIndex(cube.r0, cube.g0, cube.b1)] -
+			moment[wuIndex(cube.r0, cube.g0, cube.b0)]
+	case wuGreen:
+		return -moment[wuIndex(cube.r1, cube.g0, cube.b1)] +
+			moment[wuIndex(cube.r1, cube.g0, cube.b0)] +
+			moment[wuIndex(cube.r0, cube.g0, cube.b1)] -
+			moment[wuIndex(cube.r0, cube.g0, cube.b0)]
+	default:
+		return -moment[wuIndex(cube.r1, cube.g1, cube.b0)] +
+			moment[wuIndex(cube.r1, cube.g0, cube.b0)] +
+			moment[wuIndex(cube.r0, cube.g1, cube.b0)] -
+			moment[wuIndex(cube.r0, cube.g0, cube.b0)]
+	}
+}
+
+func (q *wuQuantizer) top(cube wuBox, direction wuDirection, position int, moment []float64) float64 {
+	switch direction {
+	case wuRed:
+		return moment[wuIndex(position, cube.g1, cube.b1)] -
+			moment[wuIndex(position, cube.g1, cube.b0)] -
+			moment[wuIndex(position, cube.g0, cube.b1)] +
+			moment[wuIndex(position, cube.g0, cube.b0)]
+	case wuGreen:
+		return moment[wuIndex(cube.r1, position, cube.b1)] -
+			moment[wuIndex(cube.r1, position, cube.b0)] -
+			moment[wuIndex(cube.r0, position, cube.b1)] +
+			moment[wuIndex(cube.r0, position, cube.b0)]
+	default:
+		return moment[wuIndex(cube.r1, cube.g1, position)] -
+			moment[wuIndex(cube.r1, cube.g0, position)] -
+			moment[wuIndex(cube.r0, cube.g1, position)] +
+			moment[wuIndex(cube.r0, cube.g0, position)]
+	}
+}
+
+func wuIndex(r int, g int, b int) int {
+	return (r << (wuIndexBits * 2)) + (r << (wuIndexBits + 1)) + r + (g << wuIndexBits) + g + b
+}
+
+// ---------------------------------------------------------------------------------------------
+// Weighted k-means
+// ---------------------------------------------------------------------------------------------
+
+const (
+	wsmeansMaxIterations       = 10
+	wsmeansMinMovementDistance = 3.0
+)
+
+// quantizeWsmeans clusters the pixels in L*a*b* with a k-means weighted by the number of
+// occurrences of each color, starting from the given clusters.
+func quantizeWsmeans(pixels []int, startingClusters []int, maxColors int) map[int]int {
+	pixelToCount := make(map[int]int)
+	var points [][3]float64
+	var uniquePixels []int
+	for _, pixel := range pixels {
+		if _, ok := pixelToCount[pixel]; !ok {
+			l, a, b := labFromArgb(pixel)
+			points = append(points, [3]float64{l, a, b})
+			uniquePixels = append(uniquePixels, pixel)
+		}
+		pixelToCount[pixel]++
+	}
+	counts := make([]int, len(uniquePixels))
+	for i, pixel := range uniquePixels {
+		counts[i] = pixelToCount[pixel]
+	}
+
+	clusterCount := maxColors
+	if len(points) < clusterCount {
+		clusterCount = len(points)
+	}
+	if len(startingClusters) > 0 && len(startingClusters) < clusterCount {
+		clusterCount = len(startingClusters)
+	}
+	if clusterCount == 0 {
+		return map[int]int{}
+	}
+
+	clusters := make([][3]float64, clusterCount)
+	for i := range clusters {
+		if i < len(startingClusters) {
+			l, a, b := labFromArgb(startingClusters[i])
+			clusters[i] = [3]float64{l, a, b}
+		} else {
+			clusters[i] = points[i]
+		}
+	}
+
+	random := rand.New(rand.NewSource(0x42688))
+	clusterIndices := make([]int, len(points))
+	for i := range clusterIndices {
+		clusterIndices[i] = random.Intn(clusterCount)
+	}
+
+	clusterDistances := make([][]float64, clusterCount)
+	for i := range clusterDistances {
+		clusterDistances[i] = make([]float64, clusterCount)
+	}
+	pixelCountSums := make([]int, clusterCount)
+	for iteration := 0; iteration < wsmeansMaxIterations; iteration++ {
+		for i := 0; i < clusterCount; i++ {
+			for j := i + 1; j < clusterCount; j++ {
+				distance := labDistance(clusters[i], clusters[j])
+				clusterDistances[i][j] = distance
+				clusterDistances[j][i] = distance
+			}
+		}
+
+		pointsMoved := 0
+		for i, point := range points {
+			previousClusterIndex := clusterIndices[i]
+			previousDistance := labDistance(point, clusters[previousClusterIndex])
+			minimumDistance := previousDistance
+			newClusterIndex := -1
+			for j := 0; j < clusterCount; j++ {
+				// By the triangle inequality, a cluster this far away cannot be closer.
+				if clusterDistances[previousClusterIndex][j] >= 4*previousDistance {
+					continue
+				}
+				distance := labDistance(point, clusters[j])
+				if distance < minimumDistance {
+					minimumDistance = distance
+					newClusterIndex = j
+				}
+			}
+			if newClusterIndex != -1 {
+				distanceChange := math.Abs(math.Sqrt(minimumDistance) - math.Sqrt(previousDistance))
+				if distanceChange > wsmeansMinMovementDistance {
+					pointsMoved++
+					clusterIndices[i] = newClusterIndex
+				}
+			}
+		}
+		if pointsMoved == 0 && iteration != 0 {
+			break
+		}
+
+		sums := make([][3]float64, clusterCount)
+		for i := range pixelCountSums {
+			pixelCountSums[i] = 0
+		}
+		for i, point := range points {
+			clusterIndex := clusterIndices[i]
+			count := float64(counts[i])
+			pixelCountSums[clusterIndex] += counts[i]
+			sums[clusterIndex][0] += point[0] * count
+			sums[clusterIndex][1] += point[1] * count
+			sums[clusterIndex][2] += point[2] * count
+		}
+		for i := range clusters {
+			count := float64(pixelCountSums[i])
+			if count == 0 {
+				clusters[i] = [3]float64{}
+				continue
+			}
+			clusters[i] = [3]float64{sums[i][0] / count, sums[i][1] / count, sums[i][2] / count}
+		}
+	}
+
+	argbToPopulation := make(map[int]int)
+	for i, cluster := range clusters {
+		if pixelCountSums[i] == 0 {
+			continue
+		}
+		argb := argbFromLab(cluster[0], cluster[1], cluster[2])
+		if _, ok := argbToPopulation[argb]; ok {
+			continue
+		}
+		argbToPopulation[argb] = pixelCountSums[i]
+	}
+	return argbToPopulation
+}
+
+// labDistance returns the squared euclidean distance between two L*a*b* colors.
+func labDistance(one [3]float64, two [3]float64) float64 {
+	dL := one[0] - two[0]
+	dA := one[1] - two[1]
+	dB := one[2] - two[2]
+	return dL*dL + dA*dA + dB*dB
+}
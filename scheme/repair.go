@@ -0,0 +1,142 @@
+// Copyright (c) 2023 https://github.com/gio-eui
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR a PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+//
+// SPDX-License-Identifier: MIT
+
+package scheme
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+)
+
+// ContrastRepair is a foreground role whose tone was changed to reach the contrast ratio of
+// its pairs against its backgrounds.
+type ContrastRepair struct {
+	Role       Role
+	Background Role // background with the lowest contrast before the repair
+
+	Old color.NRGBA
+	New color.NRGBA
+
+	OldRatio float64 // lowest contrast ratio before the repair
+	NewRatio float64 // lowest contrast ratio after the repair
+	Target   float64 // contrast ratio required by the pairs of the role
+}
+
+// Passed reports whether the repaired role reaches its target contrast ratio.
+// It does not when no tone of its hue and chroma does.
+func (r ContrastRepair) Passed() bool {
+	return r.NewRatio >= r.Target
+}
+
+// String describes the repair.
+func (r ContrastRepair) String() string {
+	return fmt.Sprintf("%s: %s (%.2f:1 on %s) -> %s (%.2f:1)", r.Role, Hex(r.Old), r.OldRatio, r.Background, Hex(r.New), r.NewRatio)
+}
+
+// RepairContrast returns a copy of the scheme where the tone of every foreground role, as listed
+// by ContrastPairs, that does not reach the WCAG 2 AA contrast ratio of its pairs against one of
+// its backgrounds is changed: 4.5:1 for text pairs and 3:1 for non-text pairs. The hue and chroma
+// of the role are kept, and the tone closest to the original one that reaches the ratio against
+// every background is used, or the tone with the best contrast if none does. Backgrounds are
+// never changed. The scheme is not modified.
+//
+// The copy stays repaired: its colors are repaired again when they are recomputed, such as by
+// WithContrastLevel.
+func (s *Scheme) RepairContrast() (*Scheme, []ContrastRepair) {
+	c := s.Clone()
+	c.repairContrast = true
+	return c, c.repair()
+}
+
+// updateRepairs repairs the contrast of the scheme in place if it was returned by RepairContrast.
+func (s *Scheme) updateRepairs() {
+	if s.repairContrast {
+		s.repair()
+	}
+}
+
+// repair repairs the contrast of the scheme in place, as described by RepairContrast.
+func (s *Scheme) repair() []ContrastRepair {
+	var foregrounds []Role
+	backgrounds := make(map[Role][]Role)
+	targets := make(map[Role]float64)
+	for _, pair := range s.ContrastPairs() {
+		if _, ok := backgrounds[pair.Foreground]; !ok {
+			foregrounds = append(foregrounds, pair.Foreground)
+		}
+		backgrounds[pair.Foreground] = append(backgrounds[pair.Foreground], pair.Background)
+		// A role paired as text and as non-text must reach the ratio of text.
+		targets[pair.Foreground] = math.Max(targets[pair.Foreground], pair.Kind.MinRatio())
+	}
+
+	var repairs []ContrastRepair
+	for _, role := range foregrounds {
+		field := s.colorField(role)
+		old, target := *field, targets[role]
+		oldRatio, worst := s.lowestContrast(old, backgrounds[role])
+		if oldRatio >= target {
+			continue
+		}
+
+		h := hctFromInt(Argb(color.NRGBA{R: old.R, G: old.G, B: old.B, A: 0xff}))
+		best, bestRatio := old, oldRatio
+		for delta := 0.5; delta <= 100 && bestRatio < target; delta += 0.5 {
+			// The darker and lighter tones at the same distance are both tried,
+			// keeping the one with the best contrast.
+			for _, tone := range []float64{h.tone - delta, h.tone + delta} {
+				if tone < 0 || tone > 100 {
+					continue
+				}
+				candidate := NRGBA(hctToInt(h.hue, h.chroma, tone))
+				candidate.A = old.A
+				if ratio, _ := s.lowestContrast(candidate, backgrounds[role]); ratio > bestRatio {
+					best, bestRatio = candidate, ratio
+				}
+			}
+		}
+
+		*field = best
+		repairs = append(repairs, ContrastRepair{
+			Role:       role,
+			Background: worst,
+			Old:        old,
+			New:        best,
+			OldRatio:   oldRatio,
+			NewRatio:   bestRatio,
+			Target:     target,
+		})
+	}
+	return repairs
+}
+
+// lowestContrast returns the lowest contrast ratio of a color against the backgrounds, and the
+// background it is reached against.
+func (s *Scheme) lowestContrast(c color.NRGBA, backgrounds []Role) (float64, Role) {
+	lowest, worst := math.Inf(1), Role("")
+	for _, background := range backgrounds {
+		bg, _ := s.Color(background)
+		if ratio := ContrastRatio(c, bg); ratio < lowest {
+			lowest, worst = ratio, background
+		}
+	}
+	return lowest, worst
+}
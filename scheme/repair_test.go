@@ -0,0 +1,115 @@
+// Copyright (c) 2023 https://github.com/gio-eui
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR a PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+//
+// SPDX-License-Identifier: MIT
+
+package scheme
+
+import (
+	"image/color"
+	"testing"
+)
+
+func TestRepairContrast(t *testing.T) {
+	s := FromSeed(0xff6750a4, VariantTonalSpot, false).WithContrastLevel(-1)
+	before := s.Clone()
+	if len(s.Audit().Failures()) == 0 {
+		t.Fatal("reduced contrast scheme has no failures to repair")
+	}
+
+	repaired, repairs := s.RepairContrast()
+	if repaired == s {
+		t.Fatal("RepairContrast returned the scheme itself")
+	}
+	for _, role := range Roles() {
+		if got, _ := s.Color(role); got != mustColor(t, before, role) {
+			t.Errorf("RepairContrast modified %s", role)
+		}
+	}
+	if len(repairs) == 0 {
+		t.Fatal("no repairs")
+	}
+	for _, repair := range repairs {
+		if !repair.Passed() {
+			t.Errorf("%s: %.2f:1 below %.2f:1", repair, repair.NewRatio, repair.Target)
+		}
+		if got, _ := repaired.Color(repair.Role); got != repair.New {
+			t.Errorf("%s = %s, want %s", repair.Role, Hex(got), Hex(repair.New))
+		}
+	}
+	if failures := repaired.Audit().Failures(); len(failures) > 0 {
+		t.Errorf("failures after repair: %v", failures)
+	}
+}
+
+// mustColor returns the color of a role of the scheme.
+func mustColor(t *testing.T, s *Scheme, role Role) color.NRGBA {
+	t.Helper()
+	c, ok := s.Color(role)
+	if !ok {
+		t.Fatalf("unknown role %q", role)
+	}
+	return c
+}
+
+func TestRepairContrastTargets(t *testing.T) {
+	gray := func(v uint8) color.NRGBA { return color.NRGBA{R: v, G: v, B: v, A: 0xff} }
+	s := FromSeed(0xff6750a4, VariantTonalSpot, false)
+	s.SetColor(RoleInverseSurface, gray(0xff))
+	s.SetColor(RoleInverseOnSurface, gray(0))
+	// 3.54:1 is enough for a non-text pair, not for a text pair.
+	s.SetColor(RoleInversePrimary, gray(0x88))
+	s.SetColor(RoleOnPrimary, gray(0x88))
+	s.SetColor(RolePrimary, gray(0xff))
+
+	_, repairs := s.RepairContrast()
+	targets := make(map[Role]float64)
+	for _, repair := range repairs {
+		targets[repair.Role] = repair.Target
+	}
+	if _, ok := targets[RoleInversePrimary]; ok {
+		t.Errorf("%s repaired above 3:1", RoleInversePrimary)
+	}
+	if target, ok := targets[RoleOnPrimary]; !ok || target != WCAGAA {
+		t.Errorf("%s target = %v, %v, want %v", RoleOnPrimary, target, ok, WCAGAA)
+	}
+}
+
+func TestRepairContrastKept(t *testing.T) {
+	repaired, _ := FromSeed(0xff6750a4, VariantTonalSpot, true).RepairContrast()
+
+	for _, level := range []float64{-1, -0.5} {
+		s := repaired.WithContrastLevel(level)
+		if failures := s.Audit().Failures(); len(failures) > 0 {
+			t.Errorf("contrast level %v: failures after WithContrastLevel: %v", level, failures)
+		}
+	}
+
+	data, err := repaired.MarshalJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded Scheme
+	if err := decoded.UnmarshalJSON(data); err != nil {
+		t.Fatal(err)
+	}
+	if failures := decoded.WithContrastLevel(-1).Audit().Failures(); len(failures) > 0 {
+		t.Errorf("failures after decoding: %v", failures)
+	}
+}
@@ -0,0 +1,222 @@
+// Copyright (c) 2023 https://github.com/gio-eui
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR a PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+//
+// SPDX-License-Identifier: MIT
+
+package scheme
+
+import "image/color"
+
+// Role is the MD3 name of a color role of a scheme, such as "primary" or "onPrimaryContainer".
+type Role string
+
+// Color roles of a scheme.
+const (
+	RolePrimary                 Role = "primary"
+	RoleOnPrimary               Role = "onPrimary"
+	RolePrimaryContainer        Role = "primaryContainer"
+	RoleOnPrimaryContainer      Role = "onPrimaryContainer"
+	RoleInversePrimary          Role = "inversePrimary"
+	RolePrimaryFixed            Role = "primaryFixed"
+	RolePrimaryFixedDim         Role = "primaryFixedDim"
+	RoleOnPrimaryFixed          Role = "onPrimaryFixed"
+	RoleOnPrimaryFixedVariant   Role = "onPrimaryFixedVariant"
+	RoleSecondary               Role = "secondary"
+	RoleOnSecondary             Role = "onSecondary"
+	RoleSecondaryContainer      Role = "secondaryContainer"
+	RoleOnSecondaryContainer    Role = "onSecondaryContainer"
+	RoleSecondaryFixed          Role = "secondaryFixed"
+	RoleSecondaryFixedDim       Role = "secondaryFixedDim"
+	RoleOnSecondaryFixed        Role = "onSecondaryFixed"
+	RoleOnSecondaryFixedVariant Role = "onSecondaryFixedVariant"
+	RoleTertiary                Role = "tertiary"
+	RoleOnTertiary              Role = "onTertiary"
+	RoleTertiaryContainer       Role = "tertiaryContainer"
+	RoleOnTertiaryContainer     Role = "onTertiaryContainer"
+	RoleTertiaryFixed           Role = "tertiaryFixed"
+	RoleTertiaryFixedDim        Role = "tertiaryFixedDim"
+	RoleOnTertiaryFixed         Role = "onTertiaryFixed"
+	RoleOnTertiaryFixedVariant  Role = "onTertiaryFixedVariant"
+	RoleCustom                  Role = "custom"
+	RoleOnCustom                Role = "onCustom"
+	RoleCustomContainer         Role = "customContainer"
+	RoleOnCustomContainer       Role = "onCustomContainer"
+	RoleCustomFixed             Role = "customFixed"
+	RoleCustomFixedDim          Role = "customFixedDim"
+	RoleOnCustomFixed           Role = "onCustomFixed"
+	RoleOnCustomFixedVariant    Role = "onCustomFixedVariant"
+	RoleError                   Role = "error"
+	RoleOnError                 Role = "onError"
+	RoleErrorContainer          Role = "errorContainer"
+	RoleOnErrorContainer        Role = "onErrorContainer"
+	RoleSurface                 Role = "surface"
+	RoleSurfaceDim              Role = "surfaceDim"
+	RoleSurfaceBright           Role = "surfaceBright"
+	RoleSurfaceContainerLowest  Role = "surfaceContainerLowest"
+	RoleSurfaceContainerLow     Role = "surfaceContainerLow"
+	RoleSurfaceContainer        Role = "surfaceContainer"
+	RoleSurfaceContainerHigh    Role = "surfaceContainerHigh"
+	RoleSurfaceContainerHighest Role = "surfaceContainerHighest"
+	RoleSurfaceVariant          Role = "surfaceVariant"
+	RoleOnSurface               Role = "onSurface"
+	RoleOnSurfaceVariant        Role = "onSurfaceVariant"
+	RoleInverseSurface          Role = "inverseSurface"
+	RoleInverseOnSurface        Role = "inverseOnSurface"
+	RoleBackground              Role = "background"
+	RoleOnBackground            Role = "onBackground"
+	RoleOutline                 Role = "outline"
+	RoleOutlineVariant          Role = "outlineVariant"
+	RoleShadow                  Role = "shadow"
+	RoleShadowTint              Role = "surfaceTint" // ShadowTint, named surface tint in MD3
+	RoleScrim                   Role = "scrim"
+)
+
+// roles maps each color role to its field, in the order of the Scheme struct.
+var roles = []struct {
+	role  Role
+	field func(s *Scheme) *color.NRGBA
+}{
+	{RolePrimary, func(s *Scheme) *color.NRGBA { return &s.Primary }},
+	{RoleOnPrimary, func(s *Scheme) *color.NRGBA { return &s.OnPrimary }},
+	{RolePrimaryContainer, func(s *Scheme) *color.NRGBA { return &s.PrimaryContainer }},
+	{RoleOnPrimaryContainer, func(s *Scheme) *color.NRGBA { return &s.OnPrimaryContainer }},
+	{RoleInversePrimary, func(s *Scheme) *color.NRGBA { return &s.InversePrimary }},
+	{RolePrimaryFixed, func(s *Scheme) *color.NRGBA { return &s.PrimaryFixed }},
+	{RolePrimaryFixedDim, func(s *Scheme) *color.NRGBA { return &s.PrimaryFixedDim }},
+	{RoleOnPrimaryFixed, func(s *Scheme) *color.NRGBA { return &s.OnPrimaryFixed }},
+	{RoleOnPrimaryFixedVariant, func(s *Scheme) *color.NRGBA { return &s.OnPrimaryFixedVariant }},
+	{RoleSecondary, func(s *Scheme) *color.NRGBA { return &s.Secondary }},
+	{RoleOnSecondary, func(s *Scheme) *color.NRGBA { return &s.OnSecondary }},
+	{RoleSecondaryContainer, func(s *Scheme) *color.NRGBA { return &s.SecondaryContainer }},
+	{RoleOnSecondaryContainer, func(s *Scheme) *color.NRGBA { return &s.OnSecondaryContainer }},
+	{RoleSecondaryFixed, func(s *Scheme) *color.NRGBA { return &s.SecondaryFixed }},
+	{RoleSecondaryFixedDim, func(s *Scheme) *color.NRGBA { return &s.SecondaryFixedDim }},
+	{RoleOnSecondaryFixed, func(s *Scheme) *color.NRGBA { return &s.OnSecondaryFixed }},
+	{RoleOnSecondaryFixedVariant, func(s *Scheme) *color.NRGBA { return &s.OnSecondaryFixedVariant }},
+	{RoleTertiary, func(s *Scheme) *color.NRGBA { return &s.Tertiary }},
+	{RoleOnTertiary, func(s *Scheme) *color.NRGBA { return &s.OnTertiary }},
+	{RoleTertiaryContainer, func(s *Scheme) *color.NRGBA { return &s.TertiaryContainer }},
+	{RoleOnTertiaryContainer, func(s *Scheme) *color.NRGBA { return &s.OnTertiaryContainer }},
+	{RoleTertiaryFixed, func(s *Scheme) *color.NRGBA { return &s.TertiaryFixed }},
+	{RoleTertiaryFixedDim, func(s *Scheme) *color.NRGBA { return &s.TertiaryFixedDim }},
+	{RoleOnTertiaryFixed, func(s *Scheme) *color.NRGBA { return &s.OnTertiaryFixed }},
+	{RoleOnTertiaryFixedVariant, func(s *Scheme) *color.NRGBA { return &s.OnTertiaryFixedVariant }},
+	{RoleCustom, func(s *Scheme) *color.NRGBA { return &s.Custom }},
+	{RoleOnCustom, func(s *Scheme) *color.NRGBA { return &s.OnCustom }},
+	{RoleCustomContainer, func(s *Scheme) *color.NRGBA { return &s.CustomContainer }},
+	{RoleOnCustomContainer, func(s *Scheme) *color.NRGBA { return &s.OnCustomContainer }},
+	{RoleCustomFixed, func(s *Scheme) *color.NRGBA { return &s.CustomFixed }},
+	{RoleCustomFixedDim, func(s *Scheme) *color.NRGBA { return &s.CustomFixedDim }},
+	{RoleOnCustomFixed, func(s *Scheme) *color.NRGBA { return &s.OnCustomFixed }},
+	{RoleOnCustomFixedVariant, func(s *Scheme) *color.NRGBA { return &s.OnCustomFixedVariant }},
+	{RoleError, func(s *Scheme) *color.NRGBA { return &s.Error }},
+	{RoleOnError, func(s *Scheme) *color.NRGBA { return &s.OnError }},
+	{RoleErrorContainer, func(s *Scheme) *color.NRGBA { return &s.ErrorContainer }},
+	{RoleOnErrorContainer, func(s *Scheme) *color.NRGBA { return &s.OnErrorContainer }},
+	{RoleSurface, func(s *Scheme) *color.NRGBA { return &s.Surface }},
+	{RoleSurfaceDim, func(s *Scheme) *color.NRGBA { return &s.SurfaceDim }},
+	{RoleSurfaceBright, func(s *Scheme) *color.NRGBA { return &s.SurfaceBright }},
+	{RoleSurfaceContainerLowest, func(s *Scheme) *color.NRGBA { return &s.SurfaceContainerLowest }},
+	{RoleSurfaceContainerLow, func(s *Scheme) *color.NRGBA { return &s.SurfaceContainerLow }},
+	{RoleSurfaceContainer, func(s *Scheme) *color.NRGBA { return &s.SurfaceContainer }},
+	{RoleSurfaceContainerHigh, func(s *Scheme) *color.NRGBA { return &s.SurfaceContainerHigh }},
+	{RoleSurfaceContainerHighest, func(s *Scheme) *color.NRGBA { return &s.SurfaceContainerHighest }},
+	{RoleSurfaceVariant, func(s *Scheme) *color.NRGBA { return &s.SurfaceVariant }},
+	{RoleOnSurface, func(s *Scheme) *color.NRGBA { return &s.OnSurface }},
+	{RoleOnSurfaceVariant, func(s *Scheme) *color.NRGBA { return &s.OnSurfaceVariant }},
+	{RoleInverseSurface, func(s *Scheme) *color.NRGBA { return &s.InverseSurface }},
+	{RoleInverseOnSurface, func(s *Scheme) *color.NRGBA { return &s.InverseOnSurface }},
+	{RoleBackground, func(s *Scheme) *color.NRGBA { return &s.Background }},
+	{RoleOnBackground, func(s *Scheme) *color.NRGBA { return &s.OnBackground }},
+	{RoleOutline, func(s *Scheme) *color.NRGBA { return &s.Outline }},
+	{RoleOutlineVariant, func(s *Scheme) *color.NRGBA { return &s.OutlineVariant }},
+	{RoleShadow, func(s *Scheme) *color.NRGBA { return &s.Shadow }},
+	{RoleShadowTint, func(s *Scheme) *color.NRGBA { return &s.ShadowTint }},
+	{RoleScrim, func(s *Scheme) *color.NRGBA { return &s.Scrim }},
+}
+
+// Roles returns every color role of a scheme, in the order of the Scheme fields.
+func Roles() []Role {
+	r := make([]Role, len(roles))
+	for i, entry := range roles {
+		r[i] = entry.role
+	}
+	return r
+}
+
+// IsCustom reports whether the role belongs to the custom color.
+func (r Role) IsCustom() bool {
+	switch r {
+	case RoleCustom, RoleOnCustom, RoleCustomContainer, RoleOnCustomContainer,
+		RoleCustomFixed, RoleCustomFixedDim, RoleOnCustomFixed, RoleOnCustomFixedVariant:
+		return true
+	}
+	return false
+}
+
+// Color returns the color of a role, and false if the role is unknown.
+// The roles of the extended colors of the scheme, as returned by ExtendedRole, are known.
+func (s *Scheme) Color(role Role) (color.NRGBA, bool) {
+	field := s.colorField(role)
+	if field == nil {
+		return color.NRGBA{}, false
+	}
+	return *field, true
+}
+
+// SetColor sets the color of a role, and returns false if the role is unknown.
+// The roles of the extended colors of the scheme, as returned by ExtendedRole, are known.
+// Unlike the With methods, SetColor changes the scheme itself: call it on a Clone of a scheme
+// that may be shared.
+func (s *Scheme) SetColor(role Role, c color.NRGBA) bool {
+	field := s.colorField(role)
+	if field == nil {
+		return false
+	}
+	*field = c
+	return true
+}
+
+// colorField returns the field of the scheme holding the color of a role, or nil if the role
+// is unknown.
+func (s *Scheme) colorField(role Role) *color.NRGBA {
+	if field := roleField(role); field != nil {
+		return field(s)
+	}
+	return s.extendedField(role)
+}
+
+// roleField returns the accessor of the field of a role, or nil if the role is unknown.
+func roleField(role Role) func(s *Scheme) *color.NRGBA {
+	for _, entry := range roles {
+		if entry.role == role {
+			return entry.field
+		}
+	}
+	return nil
+}
+
+// toneFields returns the fields of the scheme holding the tone 50 of its tonal palettes,
+// which are not roles.
+func toneFields(s *Scheme) []*color.NRGBA {
+	return []*color.NRGBA{
+		&s.PrimaryTone, &s.SecondaryTone, &s.TertiaryTone, &s.CustomTone,
+		&s.NeutralTone, &s.NeutralVariantTone, &s.ErrorTone,
+	}
+}
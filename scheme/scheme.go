@@ -27,6 +27,11 @@ import (
 )
 
 // Scheme is a collection of colors that are used to represent the UI of an app.
+//
+// The With methods return a modified copy of the scheme and never change it, so that a scheme
+// can be shared, such as by the goroutines using a palette. Assigning the color fields and
+// SetColor change the scheme itself: a scheme that may be shared, such as one returned by
+// a palette, must not be written, but cloned with Clone first.
 type Scheme struct {
 	// The primary key color is used to derive roles for key components across the UI,
 	// such as the FAB, prominent buttons, active states, as well as the tint of elevated surfaces.
@@ -35,31 +40,48 @@ type Scheme struct {
 	PrimaryContainer   color.NRGBA // primary100 / primary20
 	OnPrimaryContainer color.NRGBA // primary10 / primary90
 	InversePrimary     color.NRGBA // primary80 /primary40
+	// Fixed colors keep the same tone in light and dark schemes.
+	PrimaryFixed          color.NRGBA // primary90 / primary90
+	PrimaryFixedDim       color.NRGBA // primary80 / primary80
+	OnPrimaryFixed        color.NRGBA // primary10 / primary10
+	OnPrimaryFixedVariant color.NRGBA // primary30 / primary30
 
 	// The secondary key color is used for less prominent components in the UI such as filter chips,
 	// while expanding the opportunity for color expression.
-	Secondary            color.NRGBA // secondary40 / secondary80
-	OnSecondary          color.NRGBA // secondary90 / secondary30
-	SecondaryContainer   color.NRGBA // secondary100 / secondary20
-	OnSecondaryContainer color.NRGBA // secondary10 / secondary90
+	Secondary               color.NRGBA // secondary40 / secondary80
+	OnSecondary             color.NRGBA // secondary90 / secondary30
+	SecondaryContainer      color.NRGBA // secondary100 / secondary20
+	OnSecondaryContainer    color.NRGBA // secondary10 / secondary90
+	SecondaryFixed          color.NRGBA // secondary90 / secondary90
+	SecondaryFixedDim       color.NRGBA // secondary80 / secondary80
+	OnSecondaryFixed        color.NRGBA // secondary10 / secondary10
+	OnSecondaryFixedVariant color.NRGBA // secondary30 / secondary30
 
 	// The tertiary key color is used to derive the roles of contrasting accents that can be used
 	// to balance primary and secondary colors or bring heightened attention to an element.
 	// The tertiary color role is left for teams to use at their discretion and is intended
 	// to support broader color expression in products.
-	Tertiary            color.NRGBA // tertiary40 / tertiary80
-	OnTertiary          color.NRGBA // tertiary90 / tertiary30
-	TertiaryContainer   color.NRGBA // tertiary100 / tertiary20
-	OnTertiaryContainer color.NRGBA // tertiary10 / tertiary90
+	Tertiary               color.NRGBA // tertiary40 / tertiary80
+	OnTertiary             color.NRGBA // tertiary90 / tertiary30
+	TertiaryContainer      color.NRGBA // tertiary100 / tertiary20
+	OnTertiaryContainer    color.NRGBA // tertiary10 / tertiary90
+	TertiaryFixed          color.NRGBA // tertiary90 / tertiary90
+	TertiaryFixedDim       color.NRGBA // tertiary80 / tertiary80
+	OnTertiaryFixed        color.NRGBA // tertiary10 / tertiary10
+	OnTertiaryFixedVariant color.NRGBA // tertiary30 / tertiary30
 
 	// The custom key color is used to derive the roles of contrasting accents that can be used
 	// to balance primary and secondary colors or bring heightened attention to an element.
 	// The custom color role is left for teams to use at their discretion and is intended
 	// to support broader color expression in products.
-	Custom            color.NRGBA // custom40 / custom80
-	OnCustom          color.NRGBA // custom90 / custom30
-	CustomContainer   color.NRGBA // custom100 / custom20
-	OnCustomContainer color.NRGBA // custom10 / custom90
+	Custom               color.NRGBA // custom40 / custom80
+	OnCustom             color.NRGBA // custom90 / custom30
+	CustomContainer      color.NRGBA // custom100 / custom20
+	OnCustomContainer    color.NRGBA // custom10 / custom90
+	CustomFixed          color.NRGBA // custom90 / custom90
+	CustomFixedDim       color.NRGBA // custom80 / custom80
+	OnCustomFixed        color.NRGBA // custom10 / custom10
+	OnCustomFixedVariant color.NRGBA // custom30 / custom30
 
 	//
 	Error            color.NRGBA // error40 / error80
@@ -109,6 +131,20 @@ type Scheme struct {
 	neutralTone        *palettes.TonalPalette
 	neutralVariantTone *palettes.TonalPalette
 	errorTone          *palettes.TonalPalette
+
+	// Custom and error tonal palettes as set, before their harmonization.
+	customSource    *palettes.TonalPalette
+	errorSource     *palettes.TonalPalette
+	harmonizeCustom bool
+	harmonizeError  bool
+
+	extended []ExtendedColor
+
+	isDark         bool
+	contrastLevel  float64
+	variant        Variant
+	sourceColor    int
+	repairContrast bool // set by RepairContrast
 }
 
 // Light creates a light scheme based on the given color.
@@ -124,409 +160,617 @@ func Dark(primary int, secondary int, tertiary int, neutral int, neutralVariant
 // lightFromTonalPalette creates a light scheme based on the given core palette.
 func lightFromTonalPalette(primary *palettes.TonalPalette, secondary *palettes.TonalPalette, tertiary *palettes.TonalPalette, neutral *palettes.TonalPalette, neutralVariant *palettes.TonalPalette) *Scheme {
 	s := &Scheme{}
-	s = s.WithPrimaryTonalPalette(primary, false)
-	s = s.WithSecondaryTonalPalette(secondary, false)
-	s = s.WithTertiaryTonalPalette(tertiary, false)
-	s = s.WithNeutralTonalPalette(neutral, false)
-	s = s.WithNeutralVariantTonalPalette(neutralVariant, false)
-	s = s.WithErrorTonalPalette(ErrorTonalPalette, false)
+	s.setPrimaryTonalPalette(primary, false)
+	s.setSecondaryTonalPalette(secondary, false)
+	s.setTertiaryTonalPalette(tertiary, false)
+	s.setNeutralTonalPalette(neutral, false)
+	s.setNeutralVariantTonalPalette(neutralVariant, false)
+	s.setErrorTonalPalette(ErrorTonalPalette, false)
 	return s
 }
 
 // darkFromTonalPalette creates a dark scheme based on the given core palette.
 func darkFromTonalPalette(primary *palettes.TonalPalette, secondary *palettes.TonalPalette, tertiary *palettes.TonalPalette, neutral *palettes.TonalPalette, neutralVariant *palettes.TonalPalette) *Scheme {
 	s := &Scheme{}
-	s = s.WithPrimaryTonalPalette(primary, true)
-	s = s.WithSecondaryTonalPalette(secondary, true)
-	s = s.WithTertiaryTonalPalette(tertiary, true)
-	s = s.WithNeutralTonalPalette(neutral, true)
-	s = s.WithNeutralVariantTonalPalette(neutralVariant, true)
-	s = s.WithErrorTonalPalette(ErrorTonalPalette, true)
+	s.setPrimaryTonalPalette(primary, true)
+	s.setSecondaryTonalPalette(secondary, true)
+	s.setTertiaryTonalPalette(tertiary, true)
+	s.setNeutralTonalPalette(neutral, true)
+	s.setNeutralVariantTonalPalette(neutralVariant, true)
+	s.setErrorTonalPalette(ErrorTonalPalette, true)
 	return s
 }
 
-// WithPrimaryTonalPalette sets the primary tonal palette of the scheme.
-func (s *Scheme) WithPrimaryTonalPalette(primaryTone *palettes.TonalPalette, isDark bool) *Scheme {
-	if isDark {
-		s.Primary = s.nrgba(primaryTone.Tone(80))
-		s.OnPrimary = s.nrgba(primaryTone.Tone(20))
-		s.PrimaryContainer = s.nrgba(primaryTone.Tone(30))
-		s.OnPrimaryContainer = s.nrgba(primaryTone.Tone(90))
-		s.InversePrimary = s.nrgba(primaryTone.Tone(40))
-	} else {
-		s.Primary = s.nrgba(primaryTone.Tone(40))
-		s.OnPrimary = s.nrgba(primaryTone.Tone(100))
-		s.PrimaryContainer = s.nrgba(primaryTone.Tone(90))
-		s.OnPrimaryContainer = s.nrgba(primaryTone.Tone(10))
-		s.InversePrimary = s.nrgba(primaryTone.Tone(80))
-	}
+// setPrimaryTonalPalette sets the primary tonal palette of the scheme, in place.
+func (s *Scheme) setPrimaryTonalPalette(primaryTone *palettes.TonalPalette, isDark bool) {
+	s.primaryTone = primaryTone
+	s.isDark = isDark
+	s.Primary = s.nrgba(PrimaryDynamicColor.GetArgb(s))
+	s.OnPrimary = s.nrgba(OnPrimaryDynamicColor.GetArgb(s))
+	s.PrimaryContainer = s.nrgba(PrimaryContainerDynamicColor.GetArgb(s))
+	s.OnPrimaryContainer = s.nrgba(OnPrimaryContainerDynamicColor.GetArgb(s))
+	s.InversePrimary = s.nrgba(InversePrimaryDynamicColor.GetArgb(s))
 	s.ShadowTint = s.nrgba(primaryTone.GetKeyColor().ToInt())
+	s.PrimaryFixed = s.nrgba(PrimaryFixedDynamicColor.GetArgb(s))
+	s.PrimaryFixedDim = s.nrgba(PrimaryFixedDimDynamicColor.GetArgb(s))
+	s.OnPrimaryFixed = s.nrgba(OnPrimaryFixedDynamicColor.GetArgb(s))
+	s.OnPrimaryFixedVariant = s.nrgba(OnPrimaryFixedVariantDynamicColor.GetArgb(s))
 	s.PrimaryTone = s.nrgba(primaryTone.Tone(50))
-	s.primaryTone = primaryTone
-	return s
+	// Harmonized palettes follow the primary palette
+	if s.harmonizeCustom {
+		s.setCustomTonalPalette(s.customSource, isDark)
+	}
+	if s.harmonizeError && s.errorSource != nil {
+		s.setErrorTonalPalette(s.errorSource, isDark)
+	}
+	s.updateExtendedColors()
 }
 
-// WithSecondaryTonalPalette sets the secondary tonal palette of the scheme.
-func (s *Scheme) WithSecondaryTonalPalette(secondaryTone *palettes.TonalPalette, isDark bool) *Scheme {
-	if isDark {
-		s.Secondary = s.nrgba(secondaryTone.Tone(80))
-		s.OnSecondary = s.nrgba(secondaryTone.Tone(20))
-		s.SecondaryContainer = s.nrgba(secondaryTone.Tone(30))
-		s.OnSecondaryContainer = s.nrgba(secondaryTone.Tone(90))
-	} else {
-		s.Secondary = s.nrgba(secondaryTone.Tone(40))
-		s.OnSecondary = s.nrgba(secondaryTone.Tone(100))
-		s.SecondaryContainer = s.nrgba(secondaryTone.Tone(90))
-		s.OnSecondaryContainer = s.nrgba(secondaryTone.Tone(10))
-	}
-	s.SecondaryTone = s.nrgba(secondaryTone.Tone(50))
+// setSecondaryTonalPalette sets the secondary tonal palette of the scheme, in place.
+func (s *Scheme) setSecondaryTonalPalette(secondaryTone *palettes.TonalPalette, isDark bool) {
 	s.secondaryTone = secondaryTone
-	return s
+	s.isDark = isDark
+	s.Secondary = s.nrgba(SecondaryDynamicColor.GetArgb(s))
+	s.OnSecondary = s.nrgba(OnSecondaryDynamicColor.GetArgb(s))
+	s.SecondaryContainer = s.nrgba(SecondaryContainerDynamicColor.GetArgb(s))
+	s.OnSecondaryContainer = s.nrgba(OnSecondaryContainerDynamicColor.GetArgb(s))
+	s.SecondaryFixed = s.nrgba(SecondaryFixedDynamicColor.GetArgb(s))
+	s.SecondaryFixedDim = s.nrgba(SecondaryFixedDimDynamicColor.GetArgb(s))
+	s.OnSecondaryFixed = s.nrgba(OnSecondaryFixedDynamicColor.GetArgb(s))
+	s.OnSecondaryFixedVariant = s.nrgba(OnSecondaryFixedVariantDynamicColor.GetArgb(s))
+	s.SecondaryTone = s.nrgba(secondaryTone.Tone(50))
 }
 
-// WithTertiaryTonalPalette sets the tertiary tonal palette of the scheme.
-func (s *Scheme) WithTertiaryTonalPalette(tertiaryTone *palettes.TonalPalette, isDark bool) *Scheme {
-	if isDark {
-		s.Tertiary = s.nrgba(tertiaryTone.Tone(80))
-		s.OnTertiary = s.nrgba(tertiaryTone.Tone(20))
-		s.TertiaryContainer = s.nrgba(tertiaryTone.Tone(30))
-		s.OnTertiaryContainer = s.nrgba(tertiaryTone.Tone(90))
-	} else {
-		s.Tertiary = s.nrgba(tertiaryTone.Tone(40))
-		s.OnTertiary = s.nrgba(tertiaryTone.Tone(100))
-		s.TertiaryContainer = s.nrgba(tertiaryTone.Tone(90))
-		s.OnTertiaryContainer = s.nrgba(tertiaryTone.Tone(10))
-	}
-	s.TertiaryTone = s.nrgba(tertiaryTone.Tone(50))
+// setTertiaryTonalPalette sets the tertiary tonal palette of the scheme, in place.
+func (s *Scheme) setTertiaryTonalPalette(tertiaryTone *palettes.TonalPalette, isDark bool) {
 	s.tertiaryTone = tertiaryTone
-	return s
+	s.isDark = isDark
+	s.Tertiary = s.nrgba(TertiaryDynamicColor.GetArgb(s))
+	s.OnTertiary = s.nrgba(OnTertiaryDynamicColor.GetArgb(s))
+	s.TertiaryContainer = s.nrgba(TertiaryContainerDynamicColor.GetArgb(s))
+	s.OnTertiaryContainer = s.nrgba(OnTertiaryContainerDynamicColor.GetArgb(s))
+	s.TertiaryFixed = s.nrgba(TertiaryFixedDynamicColor.GetArgb(s))
+	s.TertiaryFixedDim = s.nrgba(TertiaryFixedDimDynamicColor.GetArgb(s))
+	s.OnTertiaryFixed = s.nrgba(OnTertiaryFixedDynamicColor.GetArgb(s))
+	s.OnTertiaryFixedVariant = s.nrgba(OnTertiaryFixedVariantDynamicColor.GetArgb(s))
+	s.TertiaryTone = s.nrgba(tertiaryTone.Tone(50))
 }
 
-// WithCustomTonalPalette sets the custom tonal palette of the scheme.
-func (s *Scheme) WithCustomTonalPalette(customTone *palettes.TonalPalette, isDark bool) *Scheme {
+// setCustomTonalPalette sets the custom tonal palette of the scheme, in place.
+func (s *Scheme) setCustomTonalPalette(customTone *palettes.TonalPalette, isDark bool) {
 	if customTone == nil {
-		return s
+		return
+	}
+	s.customSource = customTone
+	s.customTone = s.harmonized(customTone, s.harmonizeCustom)
+	s.isDark = isDark
+	s.Custom = s.nrgba(CustomDynamicColor.GetArgb(s))
+	s.OnCustom = s.nrgba(OnCustomDynamicColor.GetArgb(s))
+	s.CustomContainer = s.nrgba(CustomContainerDynamicColor.GetArgb(s))
+	s.OnCustomContainer = s.nrgba(OnCustomContainerDynamicColor.GetArgb(s))
+	s.CustomFixed = s.nrgba(CustomFixedDynamicColor.GetArgb(s))
+	s.CustomFixedDim = s.nrgba(CustomFixedDimDynamicColor.GetArgb(s))
+	s.OnCustomFixed = s.nrgba(OnCustomFixedDynamicColor.GetArgb(s))
+	s.OnCustomFixedVariant = s.nrgba(OnCustomFixedVariantDynamicColor.GetArgb(s))
+	s.CustomTone = s.nrgba(s.customTone.Tone(50))
+}
+
+// setNeutralTonalPalette sets the neutral tonal palette of the scheme, in place.
+func (s *Scheme) setNeutralTonalPalette(neutralTone *palettes.TonalPalette, isDark bool) {
+	s.neutralTone = neutralTone
+	s.isDark = isDark
+	s.Surface = s.nrgba(SurfaceDynamicColor.GetArgb(s))
+	s.SurfaceDim = s.nrgba(SurfaceDimDynamicColor.GetArgb(s))
+	s.SurfaceBright = s.nrgba(SurfaceBrightDynamicColor.GetArgb(s))
+	s.SurfaceContainerLowest = s.nrgba(SurfaceContainerLowestDynamicColor.GetArgb(s))
+	s.SurfaceContainerLow = s.nrgba(SurfaceContainerLowDynamicColor.GetArgb(s))
+	s.SurfaceContainer = s.nrgba(SurfaceContainerDynamicColor.GetArgb(s))
+	s.SurfaceContainerHigh = s.nrgba(SurfaceContainerHighDynamicColor.GetArgb(s))
+	s.SurfaceContainerHighest = s.nrgba(SurfaceContainerHighestDynamicColor.GetArgb(s))
+	s.OnSurface = s.nrgba(OnSurfaceDynamicColor.GetArgb(s))
+	s.InverseSurface = s.nrgba(InverseSurfaceDynamicColor.GetArgb(s))
+	s.InverseOnSurface = s.nrgba(InverseOnSurfaceDynamicColor.GetArgb(s))
+	s.Background = s.nrgba(BackgroundDynamicColor.GetArgb(s))
+	s.OnBackground = s.nrgba(OnBackgroundDynamicColor.GetArgb(s))
+	s.Shadow = s.nrgba(ShadowDynamicColor.GetArgb(s))
+	s.Scrim = s.nrgba(ScrimDynamicColor.GetArgb(s))
+	s.NeutralTone = s.nrgba(neutralTone.Tone(50))
+}
+
+// setNeutralVariantTonalPalette sets the neutral variant tonal palette of the scheme, in place.
+func (s *Scheme) setNeutralVariantTonalPalette(neutralVariantTone *palettes.TonalPalette, isDark bool) {
+	s.neutralVariantTone = neutralVariantTone
+	s.isDark = isDark
+	s.SurfaceVariant = s.nrgba(SurfaceVariantDynamicColor.GetArgb(s))
+	s.OnSurfaceVariant = s.nrgba(OnSurfaceVariantDynamicColor.GetArgb(s))
+	s.Outline = s.nrgba(OutlineDynamicColor.GetArgb(s))
+	s.OutlineVariant = s.nrgba(OutlineVariantDynamicColor.GetArgb(s))
+	s.NeutralVariantTone = s.nrgba(neutralVariantTone.Tone(50))
+}
+
+// setErrorTonalPalette sets the error tonal palette of the scheme, in place.
+func (s *Scheme) setErrorTonalPalette(errorTone *palettes.TonalPalette, isDark bool) {
+	s.errorSource = errorTone
+	s.errorTone = s.harmonized(errorTone, s.harmonizeError)
+	s.isDark = isDark
+	s.Error = s.nrgba(ErrorDynamicColor.GetArgb(s))
+	s.OnError = s.nrgba(OnErrorDynamicColor.GetArgb(s))
+	s.ErrorContainer = s.nrgba(ErrorContainerDynamicColor.GetArgb(s))
+	s.OnErrorContainer = s.nrgba(OnErrorContainerDynamicColor.GetArgb(s))
+	s.ErrorTone = s.nrgba(s.errorTone.Tone(50))
+}
+
+// WithContrastLevel returns a copy of the scheme with the given contrast level, from -1 (reduced)
+// to 1 (high), whose colors are recomputed from every tonal palette of the scheme.
+// Colors set individually, such as with WithPrimary, are overwritten, and the colors of a scheme
+// returned by RepairContrast are repaired again.
+func (s *Scheme) WithContrastLevel(contrastLevel float64) *Scheme {
+	c := s.Clone()
+	c.contrastLevel = clamp(-1, 1, contrastLevel)
+	if c.primaryTone != nil {
+		c.setPrimaryTonalPalette(c.primaryTone, c.isDark)
 	}
-	if isDark {
-		s.Custom = s.nrgba(customTone.Tone(80))
-		s.OnCustom = s.nrgba(customTone.Tone(20))
-		s.CustomContainer = s.nrgba(customTone.Tone(30))
-		s.OnCustomContainer = s.nrgba(customTone.Tone(90))
-	} else {
-		s.Custom = s.nrgba(customTone.Tone(40))
-		s.OnCustom = s.nrgba(customTone.Tone(100))
-		s.CustomContainer = s.nrgba(customTone.Tone(90))
-		s.OnCustomContainer = s.nrgba(customTone.Tone(10))
+	if c.secondaryTone != nil {
+		c.setSecondaryTonalPalette(c.secondaryTone, c.isDark)
 	}
-	s.CustomTone = s.nrgba(customTone.Tone(50))
-	s.customTone = customTone
-	return s
+	if c.tertiaryTone != nil {
+		c.setTertiaryTonalPalette(c.tertiaryTone, c.isDark)
+	}
+	c.setCustomTonalPalette(c.customSource, c.isDark)
+	if c.neutralTone != nil {
+		c.setNeutralTonalPalette(c.neutralTone, c.isDark)
+	}
+	if c.neutralVariantTone != nil {
+		c.setNeutralVariantTonalPalette(c.neutralVariantTone, c.isDark)
+	}
+	if c.errorSource != nil {
+		c.setErrorTonalPalette(c.errorSource, c.isDark)
+	}
+	c.updateExtendedColors()
+	c.updateRepairs()
+	return c
+}
+
+// WithPrimaryTonalPalette returns a copy of the scheme with the given primary tonal palette.
+func (s *Scheme) WithPrimaryTonalPalette(primaryTone *palettes.TonalPalette, isDark bool) *Scheme {
+	c := s.Clone()
+	c.setPrimaryTonalPalette(primaryTone, isDark)
+	c.updateRepairs()
+	return c
+}
+
+// WithSecondaryTonalPalette returns a copy of the scheme with the given secondary tonal palette.
+func (s *Scheme) WithSecondaryTonalPalette(secondaryTone *palettes.TonalPalette, isDark bool) *Scheme {
+	c := s.Clone()
+	c.setSecondaryTonalPalette(secondaryTone, isDark)
+	c.updateRepairs()
+	return c
 }
 
-// WithNeutralTonalPalette sets the neutral tonal palette of the scheme.
+// WithTertiaryTonalPalette returns a copy of the scheme with the given tertiary tonal palette.
+func (s *Scheme) WithTertiaryTonalPalette(tertiaryTone *palettes.TonalPalette, isDark bool) *Scheme {
+	c := s.Clone()
+	c.setTertiaryTonalPalette(tertiaryTone, isDark)
+	c.updateRepairs()
+	return c
+}
+
+// WithCustomTonalPalette returns a copy of the scheme with the given custom tonal palette.
+func (s *Scheme) WithCustomTonalPalette(customTone *palettes.TonalPalette, isDark bool) *Scheme {
+	c := s.Clone()
+	c.setCustomTonalPalette(customTone, isDark)
+	c.updateRepairs()
+	return c
+}
+
+// WithNeutralTonalPalette returns a copy of the scheme with the given neutral tonal palette.
 func (s *Scheme) WithNeutralTonalPalette(neutralTone *palettes.TonalPalette, isDark bool) *Scheme {
-	if isDark {
-		s.Surface = s.nrgba(neutralTone.Tone(6))
-		s.SurfaceDim = s.nrgba(neutralTone.Tone(6))
-		s.SurfaceBright = s.nrgba(neutralTone.Tone(24))
-		s.SurfaceContainerLowest = s.nrgba(neutralTone.Tone(4))
-		s.SurfaceContainerLow = s.nrgba(neutralTone.Tone(10))
-		s.SurfaceContainer = s.nrgba(neutralTone.Tone(12))
-		s.SurfaceContainerHigh = s.nrgba(neutralTone.Tone(17))
-		s.SurfaceContainerHighest = s.nrgba(neutralTone.Tone(22))
-		s.OnSurface = s.nrgba(neutralTone.Tone(90))
-		s.InverseSurface = s.nrgba(neutralTone.Tone(90))
-		s.InverseOnSurface = s.nrgba(neutralTone.Tone(20))
-		s.Background = s.nrgba(neutralTone.Tone(6))
-		s.OnBackground = s.nrgba(neutralTone.Tone(90))
-		s.Shadow = s.nrgba(neutralTone.Tone(0))
-		s.Scrim = s.nrgba(neutralTone.Tone(0))
-	} else {
-		s.Surface = s.nrgba(neutralTone.Tone(98))
-		s.SurfaceDim = s.nrgba(neutralTone.Tone(87))
-		s.SurfaceBright = s.nrgba(neutralTone.Tone(98))
-		s.SurfaceContainerLowest = s.nrgba(neutralTone.Tone(100))
-		s.SurfaceContainerLow = s.nrgba(neutralTone.Tone(96))
-		s.SurfaceContainer = s.nrgba(neutralTone.Tone(94))
-		s.SurfaceContainerHigh = s.nrgba(neutralTone.Tone(92))
-		s.SurfaceContainerHighest = s.nrgba(neutralTone.Tone(90))
-		s.OnSurface = s.nrgba(neutralTone.Tone(0))
-		s.InverseSurface = s.nrgba(neutralTone.Tone(20))
-		s.InverseOnSurface = s.nrgba(neutralTone.Tone(95))
-		s.Background = s.nrgba(neutralTone.Tone(98))
-		s.OnBackground = s.nrgba(neutralTone.Tone(0))
-		s.Shadow = s.nrgba(neutralTone.Tone(0))
-		s.Scrim = s.nrgba(neutralTone.Tone(0))
-	}
-	s.NeutralTone = s.nrgba(neutralTone.Tone(50))
-	s.neutralTone = neutralTone
-	return s
+	c := s.Clone()
+	c.setNeutralTonalPalette(neutralTone, isDark)
+	c.updateRepairs()
+	return c
 }
 
-// WithNeutralVariantTonalPalette sets the neutral variant tonal palette of the scheme.
+// WithNeutralVariantTonalPalette returns a copy of the scheme with the given neutral variant tonal palette.
 func (s *Scheme) WithNeutralVariantTonalPalette(neutralVariantTone *palettes.TonalPalette, isDark bool) *Scheme {
-	if isDark {
-		s.SurfaceVariant = s.nrgba(neutralVariantTone.Tone(30))
-		s.OnSurfaceVariant = s.nrgba(neutralVariantTone.Tone(80))
-		s.Outline = s.nrgba(neutralVariantTone.Tone(60))
-		s.OutlineVariant = s.nrgba(neutralVariantTone.Tone(30))
-	} else {
-		s.SurfaceVariant = s.nrgba(neutralVariantTone.Tone(90))
-		s.OnSurfaceVariant = s.nrgba(neutralVariantTone.Tone(30))
-		s.Outline = s.nrgba(neutralVariantTone.Tone(50))
-		s.OutlineVariant = s.nrgba(neutralVariantTone.Tone(80))
-	}
-	s.NeutralVariantTone = s.nrgba(neutralVariantTone.Tone(50))
-	s.neutralVariantTone = neutralVariantTone
-	return s
+	c := s.Clone()
+	c.setNeutralVariantTonalPalette(neutralVariantTone, isDark)
+	c.updateRepairs()
+	return c
 }
 
-// WithErrorTonalPalette sets the error tonal palette of the scheme.
+// WithErrorTonalPalette returns a copy of the scheme with the given error tonal palette.
 func (s *Scheme) WithErrorTonalPalette(errorTone *palettes.TonalPalette, isDark bool) *Scheme {
-	if isDark {
-		s.Error = s.nrgba(errorTone.Tone(80))
-		s.OnError = s.nrgba(errorTone.Tone(20))
-		s.ErrorContainer = s.nrgba(errorTone.Tone(30))
-		s.OnErrorContainer = s.nrgba(errorTone.Tone(90))
-	} else {
-		s.Error = s.nrgba(errorTone.Tone(40))
-		s.OnError = s.nrgba(errorTone.Tone(100))
-		s.ErrorContainer = s.nrgba(errorTone.Tone(90))
-		s.OnErrorContainer = s.nrgba(errorTone.Tone(10))
-	}
-	s.ErrorTone = s.nrgba(errorTone.Tone(50))
-	s.errorTone = errorTone
-	return s
+	c := s.Clone()
+	c.setErrorTonalPalette(errorTone, isDark)
+	c.updateRepairs()
+	return c
 }
 
-// WithPrimary sets the primary color of the scheme.
+// ContrastLevel returns the contrast level of the scheme.
+func (s *Scheme) ContrastLevel() float64 {
+	return s.contrastLevel
+}
+
+// Clone returns a copy of the scheme, which can be changed without changing the scheme.
+func (s *Scheme) Clone() *Scheme {
+	c := *s
+	c.extended = append([]ExtendedColor(nil), s.extended...)
+	return &c
+}
+
+// WithPrimary returns a copy of the scheme with the given primary color.
 func (s *Scheme) WithPrimary(primary int) *Scheme {
-	s.Primary = s.nrgba(primary)
-	return s
+	c := s.Clone()
+	c.Primary = c.nrgba(primary)
+	return c
 }
 
-// WithOnPrimary sets the on-primary color of the scheme.
+// WithOnPrimary returns a copy of the scheme with the given on-primary color.
 func (s *Scheme) WithOnPrimary(onPrimary int) *Scheme {
-	s.OnPrimary = s.nrgba(onPrimary)
-	return s
+	c := s.Clone()
+	c.OnPrimary = c.nrgba(onPrimary)
+	return c
 }
 
-// WithPrimaryContainer sets the primary container color of the scheme.
+// WithPrimaryContainer returns a copy of the scheme with the given primary container color.
 func (s *Scheme) WithPrimaryContainer(primaryContainer int) *Scheme {
-	s.PrimaryContainer = s.nrgba(primaryContainer)
-	return s
+	c := s.Clone()
+	c.PrimaryContainer = c.nrgba(primaryContainer)
+	return c
 }
 
-// WithOnPrimaryContainer sets the on-primary container color of the scheme.
+// WithOnPrimaryContainer returns a copy of the scheme with the given on-primary container color.
 func (s *Scheme) WithOnPrimaryContainer(onPrimaryContainer int) *Scheme {
-	s.OnPrimaryContainer = s.nrgba(onPrimaryContainer)
-	return s
+	c := s.Clone()
+	c.OnPrimaryContainer = c.nrgba(onPrimaryContainer)
+	return c
 }
 
-// WithInversePrimary sets the inverse primary color of the scheme.
+// WithInversePrimary returns a copy of the scheme with the given inverse primary color.
 func (s *Scheme) WithInversePrimary(inversePrimary int) *Scheme {
-	s.InversePrimary = s.nrgba(inversePrimary)
-	return s
+	c := s.Clone()
+	c.InversePrimary = c.nrgba(inversePrimary)
+	return c
+}
+
+// WithPrimaryFixed returns a copy of the scheme with the given primary fixed color.
+func (s *Scheme) WithPrimaryFixed(primaryFixed int) *Scheme {
+	c := s.Clone()
+	c.PrimaryFixed = c.nrgba(primaryFixed)
+	return c
+}
+
+// WithPrimaryFixedDim returns a copy of the scheme with the given primary fixed dim color.
+func (s *Scheme) WithPrimaryFixedDim(primaryFixedDim int) *Scheme {
+	c := s.Clone()
+	c.PrimaryFixedDim = c.nrgba(primaryFixedDim)
+	return c
+}
+
+// WithOnPrimaryFixed returns a copy of the scheme with the given on-primary fixed color.
+func (s *Scheme) WithOnPrimaryFixed(onPrimaryFixed int) *Scheme {
+	c := s.Clone()
+	c.OnPrimaryFixed = c.nrgba(onPrimaryFixed)
+	return c
+}
+
+// WithOnPrimaryFixedVariant returns a copy of the scheme with the given on-primary fixed variant color.
+func (s *Scheme) WithOnPrimaryFixedVariant(onPrimaryFixedVariant int) *Scheme {
+	c := s.Clone()
+	c.OnPrimaryFixedVariant = c.nrgba(onPrimaryFixedVariant)
+	return c
 }
 
-// WithSecondary sets the secondary color of the scheme.
+// WithSecondary returns a copy of the scheme with the given secondary color.
 func (s *Scheme) WithSecondary(secondary int) *Scheme {
-	s.Secondary = s.nrgba(secondary)
-	return s
+	c := s.Clone()
+	c.Secondary = c.nrgba(secondary)
+	return c
 }
 
-// WithOnSecondary sets the on-secondary color of the scheme.
+// WithOnSecondary returns a copy of the scheme with the given on-secondary color.
 func (s *Scheme) WithOnSecondary(onSecondary int) *Scheme {
-	s.OnSecondary = s.nrgba(onSecondary)
-	return s
+	c := s.Clone()
+	c.OnSecondary = c.nrgba(onSecondary)
+	return c
 }
 
-// WithSecondaryContainer sets the secondary container color of the scheme.
+// WithSecondaryContainer returns a copy of the scheme with the given secondary container color.
 func (s *Scheme) WithSecondaryContainer(secondaryContainer int) *Scheme {
-	s.SecondaryContainer = s.nrgba(secondaryContainer)
-	return s
+	c := s.Clone()
+	c.SecondaryContainer = c.nrgba(secondaryContainer)
+	return c
 }
 
-// WithOnSecondaryContainer sets the on-secondary container color of the scheme.
+// WithOnSecondaryContainer returns a copy of the scheme with the given on-secondary container color.
 func (s *Scheme) WithOnSecondaryContainer(onSecondaryContainer int) *Scheme {
-	s.OnSecondaryContainer = s.nrgba(onSecondaryContainer)
-	return s
+	c := s.Clone()
+	c.OnSecondaryContainer = c.nrgba(onSecondaryContainer)
+	return c
+}
+
+// WithSecondaryFixed returns a copy of the scheme with the given secondary fixed color.
+func (s *Scheme) WithSecondaryFixed(secondaryFixed int) *Scheme {
+	c := s.Clone()
+	c.SecondaryFixed = c.nrgba(secondaryFixed)
+	return c
+}
+
+// WithSecondaryFixedDim returns a copy of the scheme with the given secondary fixed dim color.
+func (s *Scheme) WithSecondaryFixedDim(secondaryFixedDim int) *Scheme {
+	c := s.Clone()
+	c.SecondaryFixedDim = c.nrgba(secondaryFixedDim)
+	return c
 }
 
-// WithTertiary sets the tertiary color of the scheme.
+// WithOnSecondaryFixed returns a copy of the scheme with the given on-secondary fixed color.
+func (s *Scheme) WithOnSecondaryFixed(onSecondaryFixed int) *Scheme {
+	c := s.Clone()
+	c.OnSecondaryFixed = c.nrgba(onSecondaryFixed)
+	return c
+}
+
+// WithOnSecondaryFixedVariant returns a copy of the scheme with the given on-secondary fixed variant color.
+func (s *Scheme) WithOnSecondaryFixedVariant(onSecondaryFixedVariant int) *Scheme {
+	c := s.Clone()
+	c.OnSecondaryFixedVariant = c.nrgba(onSecondaryFixedVariant)
+	return c
+}
+
+// WithTertiary returns a copy of the scheme with the given tertiary color.
 func (s *Scheme) WithTertiary(tertiary int) *Scheme {
-	s.Tertiary = s.nrgba(tertiary)
-	return s
+	c := s.Clone()
+	c.Tertiary = c.nrgba(tertiary)
+	return c
 }
 
-// WithOnTertiary sets the on-tertiary color of the scheme.
+// WithOnTertiary returns a copy of the scheme with the given on-tertiary color.
 func (s *Scheme) WithOnTertiary(onTertiary int) *Scheme {
-	s.OnTertiary = s.nrgba(onTertiary)
-	return s
+	c := s.Clone()
+	c.OnTertiary = c.nrgba(onTertiary)
+	return c
 }
 
-// WithTertiaryContainer sets the tertiary container color of the scheme.
+// WithTertiaryContainer returns a copy of the scheme with the given tertiary container color.
 func (s *Scheme) WithTertiaryContainer(tertiaryContainer int) *Scheme {
-	s.TertiaryContainer = s.nrgba(tertiaryContainer)
-	return s
+	c := s.Clone()
+	c.TertiaryContainer = c.nrgba(tertiaryContainer)
+	return c
 }
 
-// WithOnTertiaryContainer sets the on-tertiary container color of the scheme.
+// WithOnTertiaryContainer returns a copy of the scheme with the given on-tertiary container color.
 func (s *Scheme) WithOnTertiaryContainer(onTertiaryContainer int) *Scheme {
-	s.OnTertiaryContainer = s.nrgba(onTertiaryContainer)
-	return s
+	c := s.Clone()
+	c.OnTertiaryContainer = c.nrgba(onTertiaryContainer)
+	return c
+}
+
+// WithTertiaryFixed returns a copy of the scheme with the given tertiary fixed color.
+func (s *Scheme) WithTertiaryFixed(tertiaryFixed int) *Scheme {
+	c := s.Clone()
+	c.TertiaryFixed = c.nrgba(tertiaryFixed)
+	return c
+}
+
+// WithTertiaryFixedDim returns a copy of the scheme with the given tertiary fixed dim color.
+func (s *Scheme) WithTertiaryFixedDim(tertiaryFixedDim int) *Scheme {
+	c := s.Clone()
+	c.TertiaryFixedDim = c.nrgba(tertiaryFixedDim)
+	return c
+}
+
+// WithOnTertiaryFixed returns a copy of the scheme with the given on-tertiary fixed color.
+func (s *Scheme) WithOnTertiaryFixed(onTertiaryFixed int) *Scheme {
+	c := s.Clone()
+	c.OnTertiaryFixed = c.nrgba(onTertiaryFixed)
+	return c
+}
+
+// WithOnTertiaryFixedVariant returns a copy of the scheme with the given on-tertiary fixed variant color.
+func (s *Scheme) WithOnTertiaryFixedVariant(onTertiaryFixedVariant int) *Scheme {
+	c := s.Clone()
+	c.OnTertiaryFixedVariant = c.nrgba(onTertiaryFixedVariant)
+	return c
 }
 
-// WithError sets the error color of the scheme.
+// WithCustomFixed returns a copy of the scheme with the given custom fixed color.
+func (s *Scheme) WithCustomFixed(customFixed int) *Scheme {
+	c := s.Clone()
+	c.CustomFixed = c.nrgba(customFixed)
+	return c
+}
+
+// WithCustomFixedDim returns a copy of the scheme with the given custom fixed dim color.
+func (s *Scheme) WithCustomFixedDim(customFixedDim int) *Scheme {
+	c := s.Clone()
+	c.CustomFixedDim = c.nrgba(customFixedDim)
+	return c
+}
+
+// WithOnCustomFixed returns a copy of the scheme with the given on-custom fixed color.
+func (s *Scheme) WithOnCustomFixed(onCustomFixed int) *Scheme {
+	c := s.Clone()
+	c.OnCustomFixed = c.nrgba(onCustomFixed)
+	return c
+}
+
+// WithOnCustomFixedVariant returns a copy of the scheme with the given on-custom fixed variant color.
+func (s *Scheme) WithOnCustomFixedVariant(onCustomFixedVariant int) *Scheme {
+	c := s.Clone()
+	c.OnCustomFixedVariant = c.nrgba(onCustomFixedVariant)
+	return c
+}
+
+// WithError returns a copy of the scheme with the given error color.
 func (s *Scheme) WithError(err int) *Scheme {
-	s.Error = s.nrgba(err)
-	return s
+	c := s.Clone()
+	c.Error = c.nrgba(err)
+	return c
 }
 
-// WithOnError sets the on-error color of the scheme.
+// WithOnError returns a copy of the scheme with the given on-error color.
 func (s *Scheme) WithOnError(onError int) *Scheme {
-	s.OnError = s.nrgba(onError)
-	return s
+	c := s.Clone()
+	c.OnError = c.nrgba(onError)
+	return c
 }
 
-// WithErrorContainer sets the error container color of the scheme.
+// WithErrorContainer returns a copy of the scheme with the given error container color.
 func (s *Scheme) WithErrorContainer(errorContainer int) *Scheme {
-	s.ErrorContainer = s.nrgba(errorContainer)
-	return s
+	c := s.Clone()
+	c.ErrorContainer = c.nrgba(errorContainer)
+	return c
 }
 
-// WithOnErrorContainer sets the on-error container color of the scheme.
+// WithOnErrorContainer returns a copy of the scheme with the given on-error container color.
 func (s *Scheme) WithOnErrorContainer(onErrorContainer int) *Scheme {
-	s.OnErrorContainer = s.nrgba(onErrorContainer)
-	return s
+	c := s.Clone()
+	c.OnErrorContainer = c.nrgba(onErrorContainer)
+	return c
 }
 
-// WithSurface sets the surface color of the scheme.
+// WithSurface returns a copy of the scheme with the given surface color.
 func (s *Scheme) WithSurface(surface int) *Scheme {
-	s.Surface = s.nrgba(surface)
-	return s
+	c := s.Clone()
+	c.Surface = c.nrgba(surface)
+	return c
 }
 
-// WithSurfaceDim sets the dim surface color of the scheme.
+// WithSurfaceDim returns a copy of the scheme with the given dim surface color.
 func (s *Scheme) WithSurfaceDim(surfaceDim int) *Scheme {
-	s.SurfaceDim = s.nrgba(surfaceDim)
-	return s
+	c := s.Clone()
+	c.SurfaceDim = c.nrgba(surfaceDim)
+	return c
 }
 
-// WithSurfaceBright sets the bright surface color of the scheme.
+// WithSurfaceBright returns a copy of the scheme with the given bright surface color.
 func (s *Scheme) WithSurfaceBright(surfaceBright int) *Scheme {
-	s.SurfaceBright = s.nrgba(surfaceBright)
-	return s
+	c := s.Clone()
+	c.SurfaceBright = c.nrgba(surfaceBright)
+	return c
 }
 
-// WithSurfaceContainerLowest sets the lowest surface container color of the scheme.
+// WithSurfaceContainerLowest returns a copy of the scheme with the given lowest surface container color.
 func (s *Scheme) WithSurfaceContainerLowest(surfaceContainerLowest int) *Scheme {
-	s.SurfaceContainerLowest = s.nrgba(surfaceContainerLowest)
-	return s
+	c := s.Clone()
+	c.SurfaceContainerLowest = c.nrgba(surfaceContainerLowest)
+	return c
 }
 
-// WithSurfaceContainerLow sets the low surface container color of the scheme.
+// WithSurfaceContainerLow returns a copy of the scheme with the given low surface container color.
 func (s *Scheme) WithSurfaceContainerLow(surfaceContainerLow int) *Scheme {
-	s.SurfaceContainerLow = s.nrgba(surfaceContainerLow)
-	return s
+	c := s.Clone()
+	c.SurfaceContainerLow = c.nrgba(surfaceContainerLow)
+	return c
 }
 
-// WithSurfaceContainer sets the surface container color of the scheme.
+// WithSurfaceContainer returns a copy of the scheme with the given surface container color.
 func (s *Scheme) WithSurfaceContainer(surfaceContainer int) *Scheme {
-	s.SurfaceContainer = s.nrgba(surfaceContainer)
-	return s
+	c := s.Clone()
+	c.SurfaceContainer = c.nrgba(surfaceContainer)
+	return c
 }
 
-// WithSurfaceContainerHigh sets the high surface container color of the scheme.
+// WithSurfaceContainerHigh returns a copy of the scheme with the given high surface container color.
 func (s *Scheme) WithSurfaceContainerHigh(surfaceContainerHigh int) *Scheme {
-	s.SurfaceContainerHigh = s.nrgba(surfaceContainerHigh)
-	return s
+	c := s.Clone()
+	c.SurfaceContainerHigh = c.nrgba(surfaceContainerHigh)
+	return c
 }
 
-// WithSurfaceContainerHighest sets the highest surface container color of the scheme.
+// WithSurfaceContainerHighest returns a copy of the scheme with the given highest surface container color.
 func (s *Scheme) WithSurfaceContainerHighest(surfaceContainerHighest int) *Scheme {
-	s.SurfaceContainerHighest = s.nrgba(surfaceContainerHighest)
-	return s
+	c := s.Clone()
+	c.SurfaceContainerHighest = c.nrgba(surfaceContainerHighest)
+	return c
 }
 
-// WithSurfaceVariant sets the surface variant color of the scheme.
+// WithSurfaceVariant returns a copy of the scheme with the given surface variant color.
 func (s *Scheme) WithSurfaceVariant(surfaceVariant int) *Scheme {
-	s.SurfaceVariant = s.nrgba(surfaceVariant)
-	return s
+	c := s.Clone()
+	c.SurfaceVariant = c.nrgba(surfaceVariant)
+	return c
 }
 
-// WithOnSurface sets the on-surface color of the scheme.
+// WithOnSurface returns a copy of the scheme with the given on-surface color.
 func (s *Scheme) WithOnSurface(onSurface int) *Scheme {
-	s.OnSurface = s.nrgba(onSurface)
-	return s
+	c := s.Clone()
+	c.OnSurface = c.nrgba(onSurface)
+	return c
 }
 
-// WithOnSurfaceVariant sets the on-surface variant color of the scheme.
+// WithOnSurfaceVariant returns a copy of the scheme with the given on-surface variant color.
 func (s *Scheme) WithOnSurfaceVariant(onSurfaceVariant int) *Scheme {
-	s.OnSurfaceVariant = s.nrgba(onSurfaceVariant)
-	return s
+	c := s.Clone()
+	c.OnSurfaceVariant = c.nrgba(onSurfaceVariant)
+	return c
 }
 
-// WithInverseSurface sets the inverse surface color of the scheme.
+// WithInverseSurface returns a copy of the scheme with the given inverse surface color.
 func (s *Scheme) WithInverseSurface(inverseSurface int) *Scheme {
-	s.InverseSurface = s.nrgba(inverseSurface)
-	return s
+	c := s.Clone()
+	c.InverseSurface = c.nrgba(inverseSurface)
+	return c
 }
 
-// WithInverseOnSurface sets the inverse on-surface color of the scheme.
+// WithInverseOnSurface returns a copy of the scheme with the given inverse on-surface color.
 func (s *Scheme) WithInverseOnSurface(inverseOnSurface int) *Scheme {
-	s.InverseOnSurface = s.nrgba(inverseOnSurface)
-	return s
+	c := s.Clone()
+	c.InverseOnSurface = c.nrgba(inverseOnSurface)
+	return c
 }
 
-// WithBackground sets the background color of the scheme.
+// WithBackground returns a copy of the scheme with the given background color.
 func (s *Scheme) WithBackground(background int) *Scheme {
-	s.Background = s.nrgba(background)
-	return s
+	c := s.Clone()
+	c.Background = c.nrgba(background)
+	return c
 }
 
-// WithOnBackground sets the on-background color of the scheme.
+// WithOnBackground returns a copy of the scheme with the given on-background color.
 func (s *Scheme) WithOnBackground(onBackground int) *Scheme {
-	s.OnBackground = s.nrgba(onBackground)
-	return s
+	c := s.Clone()
+	c.OnBackground = c.nrgba(onBackground)
+	return c
 }
 
-// WithOutline sets the outline color of the scheme.
+// WithOutline returns a copy of the scheme with the given outline color.
 func (s *Scheme) WithOutline(outline int) *Scheme {
-	s.Outline = s.nrgba(outline)
-	return s
+	c := s.Clone()
+	c.Outline = c.nrgba(outline)
+	return c
 }
 
-// WithOutlineVariant sets the outline variant color of the scheme.
+// WithOutlineVariant returns a copy of the scheme with the given outline variant color.
 func (s *Scheme) WithOutlineVariant(outlineVariant int) *Scheme {
-	s.OutlineVariant = s.nrgba(outlineVariant)
-	return s
+	c := s.Clone()
+	c.OutlineVariant = c.nrgba(outlineVariant)
+	return c
 }
 
-// WithShadow sets the shadow color of the scheme.
+// WithShadow returns a copy of the scheme with the given shadow color.
 func (s *Scheme) WithShadow(shadow int) *Scheme {
-	s.Shadow = s.nrgba(shadow)
-	return s
+	c := s.Clone()
+	c.Shadow = c.nrgba(shadow)
+	return c
 }
 
-// WithShadowTint sets the shadow tint color of the scheme.
+// WithShadowTint returns a copy of the scheme with the given shadow tint color.
 func (s *Scheme) WithShadowTint(shadowTint int) *Scheme {
-	s.ShadowTint = s.nrgba(shadowTint)
-	return s
+	c := s.Clone()
+	c.ShadowTint = c.nrgba(shadowTint)
+	return c
 }
 
-// WithScrim sets the scrim color of the scheme.
+// WithScrim returns a copy of the scheme with the given scrim color.
 func (s *Scheme) WithScrim(scrim int) *Scheme {
-	s.Scrim = s.nrgba(scrim)
-	return s
+	c := s.Clone()
+	c.Scrim = c.nrgba(scrim)
+	return c
 }
 
 // nrgba converts an ARGB color to a color.NRGBA.
 func (s *Scheme) nrgba(argb int) color.NRGBA {
-	return color.NRGBA{
-		R: uint8(argb >> 16),
-		G: uint8(argb >> 8),
-		B: uint8(argb),
-		A: uint8(argb >> 24),
-	}
+	return NRGBA(argb)
 }
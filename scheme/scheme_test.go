@@ -0,0 +1,89 @@
+// Copyright (c) 2023 https://github.com/gio-eui
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR a PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+//
+// SPDX-License-Identifier: MIT
+
+package scheme
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/gio-eui/md3-colors/palettes"
+)
+
+func TestWithCopies(t *testing.T) {
+	s := FromSeed(0xff6750a4, VariantTonalSpot, false).
+		WithCustomTonalPalette(palettes.NewTonalPaletteFromInt(0xff2e7d32), false).
+		WithExtendedColor("warning", palettes.NewTonalPaletteFromInt(0xffffb300), true)
+	before, err := json.Marshal(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tp := palettes.NewTonalPaletteFromInt(0xff006c4c)
+	for name, with := range map[string]func() *Scheme{
+		"WithPrimary":                    func() *Scheme { return s.WithPrimary(0xff000000) },
+		"WithScrim":                      func() *Scheme { return s.WithScrim(0xffffffff) },
+		"WithPrimaryTonalPalette":        func() *Scheme { return s.WithPrimaryTonalPalette(tp, true) },
+		"WithSecondaryTonalPalette":      func() *Scheme { return s.WithSecondaryTonalPalette(tp, false) },
+		"WithTertiaryTonalPalette":       func() *Scheme { return s.WithTertiaryTonalPalette(tp, false) },
+		"WithCustomTonalPalette":         func() *Scheme { return s.WithCustomTonalPalette(tp, false) },
+		"WithNeutralTonalPalette":        func() *Scheme { return s.WithNeutralTonalPalette(tp, false) },
+		"WithNeutralVariantTonalPalette": func() *Scheme { return s.WithNeutralVariantTonalPalette(tp, false) },
+		"WithErrorTonalPalette":          func() *Scheme { return s.WithErrorTonalPalette(tp, false) },
+		"WithContrastLevel":              func() *Scheme { return s.WithContrastLevel(1) },
+		"WithHarmonization":              func() *Scheme { return s.WithHarmonization(true, true) },
+		"WithExtendedColor":              func() *Scheme { return s.WithExtendedColor("warning", tp, false) },
+		"WithoutExtendedColor":           func() *Scheme { return s.WithoutExtendedColor("warning") },
+		"RepairContrast": func() *Scheme {
+			repaired, _ := s.WithContrastLevel(-1).RepairContrast()
+			return repaired
+		},
+	} {
+		if with() == s {
+			t.Errorf("%s returned the scheme itself", name)
+		}
+		after, err := json.Marshal(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(before, after) {
+			t.Errorf("%s changed the scheme", name)
+		}
+	}
+}
+
+func TestWithExtendedColorMode(t *testing.T) {
+	tp := palettes.NewTonalPaletteFromInt(0xff2e7d32)
+	for _, isDark := range []bool{false, true} {
+		s := FromSeed(0xff6750a4, VariantTonalSpot, isDark).WithCustomTonalPalette(tp, isDark)
+		with := s.WithExtendedColor("success", tp, false)
+		if with.isDark != isDark {
+			t.Errorf("dark=%t: WithExtendedColor changed the mode of the scheme", isDark)
+		}
+		// An extended color from the custom palette has the custom roles of the scheme mode.
+		e, _ := with.ExtendedColor("success")
+		if e.Color != s.Custom || e.OnColorContainer != s.OnCustomContainer {
+			t.Errorf("dark=%t: extended color %s/%s, want %s/%s", isDark,
+				Hex(e.Color), Hex(e.OnColorContainer), Hex(s.Custom), Hex(s.OnCustomContainer))
+		}
+	}
+}
@@ -0,0 +1,155 @@
+// Copyright (c) 2023 https://github.com/gio-eui
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR a PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+//
+// SPDX-License-Identifier: MIT
+
+package scheme
+
+import (
+	"image"
+	"image/color"
+	"math"
+	"sort"
+)
+
+const (
+	scoreTargetChroma            = 48.0
+	scoreWeightProportion        = 0.7
+	scoreWeightChromaAbove       = 0.3
+	scoreWeightChromaBelow       = 0.1
+	scoreCutoffChroma            = 5.0
+	scoreCutoffExcitedProportion = 0.01
+
+	// scoreFallbackColor is used when no color of the image is suitable as a seed (Google Blue).
+	scoreFallbackColor = 0xff4285f4
+	// seedsMaxPixels is the maximum number of pixels of an image sampled by SeedsFromImage.
+	seedsMaxPixels = 128 * 128
+	// seedsMaxColors is the number of colors an image is quantized to before scoring.
+	seedsMaxColors = 128
+)
+
+// SeedsFromImage extracts up to desired seed colors from an image, ranked from the most
+// suitable to the least suitable one for FromSeed. Each color is formatted as an int
+// representing an argb color. The image can come from any decoder of the standard library,
+// such as image/png or image/jpeg. Large images are sampled, and transparent pixels ignored.
+// When no color of the image is suitable, Google Blue (0xff4285f4) is returned.
+func SeedsFromImage(img image.Image, desired int) []int {
+	bounds := img.Bounds()
+	step := 1
+	for (bounds.Dx()/step)*(bounds.Dy()/step) > seedsMaxPixels {
+		step++
+	}
+	pixels := make([]int, 0, (bounds.Dx()/step+1)*(bounds.Dy()/step+1))
+	for y := bounds.Min.Y; y < bounds.Max.Y; y += step {
+		for x := bounds.Min.X; x < bounds.Max.X; x += step {
+			c := color.NRGBAModel.Convert(img.At(x, y)).(color.NRGBA)
+			if c.A < 255 {
+				continue
+			}
+			pixels = append(pixels, argbFromRgb(int(c.R), int(c.G), int(c.B)))
+		}
+	}
+	return score(quantizeCelebi(pixels, seedsMaxColors), desired)
+}
+
+// score ranks the colors of an image by their suitability as seed colors. Colors are
+// favored when they are chromatic and when their hue is well represented in the image,
+// and the chosen colors are spread around the hue wheel as much as possible.
+func score(colorsToPopulation map[int]int, desired int) []int {
+	colors := make([]int, 0, len(colorsToPopulation))
+	for argb := range colorsToPopulation {
+		colors = append(colors, argb)
+	}
+	// Iterate in a stable order, so that ties are broken the same way on every run.
+	sort.Ints(colors)
+
+	var huePopulation [360]int
+	populationSum := 0
+	hcts := make([]hct, 0, len(colors))
+	for _, argb := range colors {
+		h := hctFromInt(argb)
+		hcts = append(hcts, h)
+		huePopulation[int(math.Floor(h.hue))] += colorsToPopulation[argb]
+		populationSum += colorsToPopulation[argb]
+	}
+
+	var hueExcitedProportions [360]float64
+	for hue := 0; hue < 360; hue++ {
+		proportion := float64(huePopulation[hue]) / float64(populationSum)
+		for i := hue - 14; i < hue+16; i++ {
+			hueExcitedProportions[int(sanitizeDegrees(float64(i)))] += proportion
+		}
+	}
+
+	type scoredHct struct {
+		hct   hct
+		score float64
+	}
+	scored := make([]scoredHct, 0, len(hcts))
+	for _, h := range hcts {
+		proportion := hueExcitedProportions[int(sanitizeDegrees(math.Round(h.hue)))]
+		if h.chroma < scoreCutoffChroma || proportion <= scoreCutoffExcitedProportion {
+			continue
+		}
+		chromaWeight := scoreWeightChromaAbove
+		if h.chroma < scoreTargetChroma {
+			chromaWeight = scoreWeightChromaBelow
+		}
+		scored = append(scored, scoredHct{
+			hct:   h,
+			score: proportion*100.0*scoreWeightProportion + (h.chroma-scoreTargetChroma)*chromaWeight,
+		})
+	}
+	sort.SliceStable(scored, func(i, j int) bool {
+		return scored[i].score > scored[j].score
+	})
+
+	// Choose the best colors whose hues differ by as many degrees as possible.
+	var chosen []hct
+	for differenceDegrees := 90; differenceDegrees >= 15; differenceDegrees-- {
+		chosen = chosen[:0]
+		for _, entry := range scored {
+			hasDuplicateHue := false
+			for _, c := range chosen {
+				if 180.0-math.Abs(math.Abs(entry.hct.hue-c.hue)-180.0) < float64(differenceDegrees) {
+					hasDuplicateHue = true
+					break
+				}
+			}
+			if !hasDuplicateHue {
+				chosen = append(chosen, entry.hct)
+			}
+			if len(chosen) >= desired {
+				break
+			}
+		}
+		if len(chosen) >= desired {
+			break
+		}
+	}
+
+	if len(chosen) == 0 {
+		return []int{scoreFallbackColor}
+	}
+	seeds := make([]int, 0, len(chosen))
+	for _, c := range chosen {
+		seeds = append(seeds, c.toInt())
+	}
+	return seeds
+}
@@ -0,0 +1,103 @@
+// Copyright (c) 2023 https://github.com/gio-eui
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR a PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+//
+// SPDX-License-Identifier: MIT
+
+package scheme
+
+import (
+	"image"
+	"image/color"
+	"reflect"
+	"testing"
+)
+
+func TestQuantizeCelebi(t *testing.T) {
+	pixels := make([]int, 0, 100)
+	for i := 0; i < 70; i++ {
+		pixels = append(pixels, 0xff0000ff)
+	}
+	for i := 0; i < 30; i++ {
+		pixels = append(pixels, 0xffff0000)
+	}
+	got := quantizeCelebi(pixels, 128)
+	want := map[int]int{0xff0000ff: 70, 0xffff0000: 30}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestScore(t *testing.T) {
+	tests := []struct {
+		name   string
+		colors map[int]int
+		want   []int
+	}{
+		{"prioritizes chroma", map[int]int{0xff000000: 1, 0xffffffff: 1, 0xff0000ff: 1}, []int{0xff0000ff}},
+		{"prioritizes chroma when proportions are equal", map[int]int{0xffff0000: 1, 0xff00ff00: 1, 0xff0000ff: 1}, []int{0xffff0000, 0xff00ff00, 0xff0000ff}},
+		{"falls back to Google Blue without chromatic colors", map[int]int{0xff000000: 1}, []int{scoreFallbackColor}},
+		{"falls back to Google Blue without colors", map[int]int{}, []int{scoreFallbackColor}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := score(test.colors, 4); !reflect.DeepEqual(got, test.want) {
+				t.Errorf("got %x, want %x", got, test.want)
+			}
+		})
+	}
+}
+
+func TestSeedsFromImage(t *testing.T) {
+	blue := color.NRGBA{B: 0xff, A: 0xff}
+	red := color.NRGBA{R: 0xff, A: 0xff}
+	tests := []struct {
+		name string
+		img  image.Image
+		want []int
+	}{
+		{"solid color", filledImage(10, 10, func(x, y int) color.NRGBA { return red }), []int{0xffff0000}},
+		{"empty image", image.NewNRGBA(image.Rect(0, 0, 0, 0)), []int{scoreFallbackColor}},
+		{"one gray pixel", filledImage(1, 1, func(x, y int) color.NRGBA { return color.NRGBA{0x80, 0x80, 0x80, 0xff} }), []int{scoreFallbackColor}},
+		{"transparent image", image.NewNRGBA(image.Rect(0, 0, 4, 4)), []int{scoreFallbackColor}},
+		{"two colors ranked by proportion", filledImage(10, 10, func(x, y int) color.NRGBA {
+			if x < 7 {
+				return blue
+			}
+			return red
+		}), []int{0xff0000ff, 0xffff0000}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := SeedsFromImage(test.img, 4); !reflect.DeepEqual(got, test.want) {
+				t.Errorf("got %x, want %x", got, test.want)
+			}
+		})
+	}
+}
+
+// filledImage returns an image of the given size whose pixels are given by f.
+func filledImage(width int, height int, f func(x int, y int) color.NRGBA) *image.NRGBA {
+	img := image.NewNRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			img.SetNRGBA(x, y, f(x, y))
+		}
+	}
+	return img
+}
@@ -0,0 +1,161 @@
+// Copyright (c) 2023 https://github.com/gio-eui
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR a PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+//
+// SPDX-License-Identifier: MIT
+
+package scheme
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+)
+
+// State is an interaction state of a component.
+type State int
+
+const (
+	StateEnabled  State = iota // no interaction
+	StateHover                 // pointer over the component
+	StateFocus                 // keyboard focus
+	StatePressed               // pressed or activated
+	StateDragged               // being dragged
+	StateDisabled              // not interactive
+)
+
+// States are all the interaction states, in declaration order.
+var States = []State{
+	StateEnabled,
+	StateHover,
+	StateFocus,
+	StatePressed,
+	StateDragged,
+	StateDisabled,
+}
+
+// Opacities of the state layers and of the disabled colors, as specified by Material Design 3.
+const (
+	HoverStateLayerOpacity   = 0.08
+	FocusStateLayerOpacity   = 0.10
+	PressedStateLayerOpacity = 0.10
+	DraggedStateLayerOpacity = 0.16
+	DisabledContentOpacity   = 0.38
+	DisabledContainerOpacity = 0.12
+)
+
+// String returns the name of the state.
+func (st State) String() string {
+	switch st {
+	case StateEnabled:
+		return "enabled"
+	case StateHover:
+		return "hover"
+	case StateFocus:
+		return "focus"
+	case StatePressed:
+		return "pressed"
+	case StateDragged:
+		return "dragged"
+	case StateDisabled:
+		return "disabled"
+	default:
+		return fmt.Sprintf("State(%d)", int(st))
+	}
+}
+
+// Opacity returns the opacity of the state layer of the state, which is 0 for the enabled and
+// disabled states.
+func (st State) Opacity() float64 {
+	switch st {
+	case StateHover:
+		return HoverStateLayerOpacity
+	case StateFocus:
+		return FocusStateLayerOpacity
+	case StatePressed:
+		return PressedStateLayerOpacity
+	case StateDragged:
+		return DraggedStateLayerOpacity
+	default:
+		return 0
+	}
+}
+
+// StateColors are the colors of a component in an interaction state.
+type StateColors struct {
+	Container color.NRGBA
+	Content   color.NRGBA
+}
+
+// StateLayer returns the colors of a component whose container and content have the given roles,
+// in an interaction state, and false if a role is unknown.
+//
+// The colors are opaque, precomposited colors: in the hover, focus, pressed and dragged states,
+// the container is overlaid with the content color at the opacity of the state, and the content
+// is unchanged. In the disabled state, the container is OnSurface at 12% over the Surface of the
+// scheme, and the content is OnSurface at 38% over that container.
+func (s *Scheme) StateLayer(container Role, content Role, st State) (StateColors, bool) {
+	bg, ok := s.Color(container)
+	if !ok {
+		return StateColors{}, false
+	}
+	fg, ok := s.Color(content)
+	if !ok {
+		return StateColors{}, false
+	}
+	if st == StateDisabled {
+		bg = Overlay(s.Surface, s.OnSurface, DisabledContainerOpacity)
+		return StateColors{
+			Container: bg,
+			Content:   Overlay(bg, s.OnSurface, DisabledContentOpacity),
+		}, true
+	}
+	return StateColors{
+		Container: Overlay(bg, fg, st.Opacity()),
+		Content:   fg,
+	}, true
+}
+
+// StateLayers returns the colors of a component whose container and content have the given
+// roles in every interaction state, indexed by state, and false if a role is unknown.
+func (s *Scheme) StateLayers(container Role, content Role) (map[State]StateColors, bool) {
+	layers := make(map[State]StateColors, len(States))
+	for _, st := range States {
+		c, ok := s.StateLayer(container, content, st)
+		if !ok {
+			return nil, false
+		}
+		layers[st] = c
+	}
+	return layers, true
+}
+
+// Overlay returns the color of a layer drawn with an opacity over a background, in sRGB like
+// the Material Design 3 state layers. The alpha of the background is kept.
+func Overlay(background color.NRGBA, layer color.NRGBA, opacity float64) color.NRGBA {
+	opacity = math.Max(0, math.Min(1, opacity*float64(layer.A)/255))
+	mix := func(b uint8, l uint8) uint8 {
+		return uint8(math.Round(float64(b)*(1-opacity) + float64(l)*opacity))
+	}
+	return color.NRGBA{
+		R: mix(background.R, layer.R),
+		G: mix(background.G, layer.G),
+		B: mix(background.B, layer.B),
+		A: background.A,
+	}
+}
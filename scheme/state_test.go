@@ -0,0 +1,83 @@
+// Copyright (c) 2023 https://github.com/gio-eui
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR a PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+//
+// SPDX-License-Identifier: MIT
+
+package scheme
+
+import (
+	"image/color"
+	"testing"
+)
+
+func TestStateLayer(t *testing.T) {
+	s := FromSeed(0xff6750a4, VariantTonalSpot, false)
+	disabledContainer := Overlay(s.Surface, s.OnSurface, 0.12)
+	tests := []struct {
+		state     State
+		opacity   float64
+		container color.NRGBA
+		content   color.NRGBA
+	}{
+		{StateEnabled, 0, s.Primary, s.OnPrimary},
+		{StateHover, 0.08, Overlay(s.Primary, s.OnPrimary, 0.08), s.OnPrimary},
+		{StateFocus, 0.10, Overlay(s.Primary, s.OnPrimary, 0.10), s.OnPrimary},
+		{StatePressed, 0.10, Overlay(s.Primary, s.OnPrimary, 0.10), s.OnPrimary},
+		{StateDragged, 0.16, Overlay(s.Primary, s.OnPrimary, 0.16), s.OnPrimary},
+		{StateDisabled, 0, disabledContainer, Overlay(disabledContainer, s.OnSurface, 0.38)},
+	}
+	for _, tt := range tests {
+		if got := tt.state.Opacity(); got != tt.opacity {
+			t.Errorf("%s: opacity %v, want %v", tt.state, got, tt.opacity)
+		}
+		got, ok := s.StateLayer(RolePrimary, RoleOnPrimary, tt.state)
+		if !ok {
+			t.Fatalf("%s: roles unknown", tt.state)
+		}
+		if got.Container != tt.container || got.Content != tt.content {
+			t.Errorf("%s: %s/%s, want %s/%s", tt.state,
+				Hex(got.Container), Hex(got.Content), Hex(tt.container), Hex(tt.content))
+		}
+	}
+	if _, ok := s.StateLayer(RolePrimary, Role("unknown"), StateHover); ok {
+		t.Error("unknown role accepted")
+	}
+}
+
+func TestOverlay(t *testing.T) {
+	white := color.NRGBA{R: 0xff, G: 0xff, B: 0xff, A: 0xff}
+	black := color.NRGBA{A: 0xff}
+	tests := []struct {
+		layer   color.NRGBA
+		opacity float64
+		want    color.NRGBA
+	}{
+		{black, 0, white},
+		{black, 1, black},
+		{black, 0.12, color.NRGBA{R: 0xe0, G: 0xe0, B: 0xe0, A: 0xff}},
+		{black, 0.38, color.NRGBA{R: 0x9e, G: 0x9e, B: 0x9e, A: 0xff}},
+		// The alpha of the layer scales its opacity.
+		{color.NRGBA{A: 0x80}, 1, color.NRGBA{R: 0x7f, G: 0x7f, B: 0x7f, A: 0xff}},
+	}
+	for _, tt := range tests {
+		if got := Overlay(white, tt.layer, tt.opacity); got != tt.want {
+			t.Errorf("Overlay(white, %v, %v) = %v, want %v", tt.layer, tt.opacity, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,187 @@
+// Copyright (c) 2023 https://github.com/gio-eui
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR a PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+//
+// SPDX-License-Identifier: MIT
+
+package scheme
+
+import (
+	"math"
+	"sort"
+)
+
+// temperatureCache computes the warm/cold relations of the colors sharing the
+// chroma and tone of an input color, as described by Ou, Woodcock and Wright.
+type temperatureCache struct {
+	input      hct
+	hctsByHue  []hct
+	hctsByTemp []hct
+	tempsByHue []float64
+	inputTemp  float64
+}
+
+// newTemperatureCache creates a temperature cache for the given color.
+func newTemperatureCache(input hct) *temperatureCache {
+	t := &temperatureCache{input: input}
+	t.hctsByHue = make([]hct, 0, 361)
+	t.tempsByHue = make([]float64, 0, 361)
+	for hue := 0; hue <= 360; hue++ {
+		h := hctFrom(float64(hue), input.chroma, input.tone)
+		t.hctsByHue = append(t.hctsByHue, h)
+		t.tempsByHue = append(t.tempsByHue, rawTemperature(h))
+	}
+	t.inputTemp = rawTemperature(input)
+	t.hctsByTemp = append([]hct{input}, t.hctsByHue...)
+	sort.SliceStable(t.hctsByTemp, func(i, j int) bool {
+		return rawTemperature(t.hctsByTemp[i]) < rawTemperature(t.hctsByTemp[j])
+	})
+	return t
+}
+
+// complement returns the color of opposite temperature, also known as the complement.
+func (t *temperatureCache) complement() hct {
+	coldest := t.hctsByTemp[0]
+	warmest := t.hctsByTemp[len(t.hctsByTemp)-1]
+	coldestTemp := rawTemperature(coldest)
+	tempRange := rawTemperature(warmest) - coldestTemp
+
+	startHueIsColdestToWarmest := isBetween(t.input.hue, coldest.hue, warmest.hue)
+	startHue, endHue := coldest.hue, warmest.hue
+	if startHueIsColdestToWarmest {
+		startHue, endHue = warmest.hue, coldest.hue
+	}
+
+	smallestError := 1000.0
+	answer := t.hctsByHue[int(math.Round(t.input.hue))]
+	complementRelativeTemp := 1.0 - t.relativeTemperature(t.input)
+	for hueAddend := 0.0; hueAddend <= 360.0; hueAddend++ {
+		hue := sanitizeDegrees(startHue + hueAddend)
+		if !isBetween(hue, startHue, endHue) {
+			continue
+		}
+		index := int(math.Round(hue))
+		relativeTemp := (t.tempsByHue[index] - coldestTemp) / tempRange
+		err := math.Abs(complementRelativeTemp - relativeTemp)
+		if err < smallestError {
+			smallestError = err
+			answer = t.hctsByHue[index]
+		}
+	}
+	return answer
+}
+
+// analogous returns count colors analogous to the input, found by dividing the color
+// wheel in the given number of divisions of equal temperature difference.
+// The input color is in the middle of the returned slice.
+func (t *temperatureCache) analogous(count int, divisions int) []hct {
+	startHue := int(math.Round(t.input.hue))
+	startHct := t.hctsByHue[startHue]
+	lastTemp := t.relativeTemperature(startHct)
+
+	allColors := []hct{startHct}
+	absoluteTotalTempDelta := 0.0
+	for i := 0; i < 360; i++ {
+		hue := int(sanitizeDegrees(float64(startHue + i)))
+		temp := t.relativeTemperature(t.hctsByHue[hue])
+		absoluteTotalTempDelta += math.Abs(temp - lastTemp)
+		lastTemp = temp
+	}
+
+	hueAddend := 1
+	tempStep := absoluteTotalTempDelta / float64(divisions)
+	totalTempDelta := 0.0
+	lastTemp = t.relativeTemperature(startHct)
+	for len(allColors) < divisions {
+		hue := int(sanitizeDegrees(float64(startHue + hueAddend)))
+		h := t.hctsByHue[hue]
+		temp := t.relativeTemperature(h)
+		totalTempDelta += math.Abs(temp - lastTemp)
+
+		desiredTotalTempDeltaForIndex := float64(len(allColors)) * tempStep
+		indexSatisfied := totalTempDelta >= desiredTotalTempDeltaForIndex
+		indexAddend := 1
+		for indexSatisfied && len(allColors) < divisions {
+			allColors = append(allColors, h)
+			desiredTotalTempDeltaForIndex = float64(len(allColors)+indexAddend) * tempStep
+			indexSatisfied = totalTempDelta >= desiredTotalTempDeltaForIndex
+			indexAddend++
+		}
+		lastTemp = temp
+		hueAddend++
+		if hueAddend > 360 {
+			for len(allColors) < divisions {
+				allColors = append(allColors, h)
+			}
+			break
+		}
+	}
+
+	answers := []hct{t.input}
+	ccwCount := (count - 1) / 2
+	for i := 1; i < ccwCount+1; i++ {
+		index := -i
+		for index < 0 {
+			index += len(allColors)
+		}
+		answers = append([]hct{allColors[index%len(allColors)]}, answers...)
+	}
+	cwCount := count - ccwCount - 1
+	for i := 1; i < cwCount+1; i++ {
+		answers = append(answers, allColors[i%len(allColors)])
+	}
+	return answers
+}
+
+// relativeTemperature returns the temperature of a color relative to the coldest
+// (0.0) and warmest (1.0) colors of the cache.
+func (t *temperatureCache) relativeTemperature(h hct) float64 {
+	coldestTemp := rawTemperature(t.hctsByTemp[0])
+	tempRange := rawTemperature(t.hctsByTemp[len(t.hctsByTemp)-1]) - coldestTemp
+	if tempRange == 0 {
+		return 0.5
+	}
+	return (rawTemperature(h) - coldestTemp) / tempRange
+}
+
+// rawTemperature returns the warmth of a color, from about -0.5 (cold) to 1.0 (warm).
+func rawTemperature(h hct) float64 {
+	_, a, b := labFromArgb(h.toInt())
+	hue := sanitizeDegrees(math.Atan2(b, a) * 180.0 / math.Pi)
+	chroma := math.Hypot(a, b)
+	return -0.5 + 0.02*math.Pow(chroma, 1.07)*math.Cos(sanitizeDegrees(hue-50.0)*math.Pi/180.0)
+}
+
+// isBetween reports whether angle lies on the arc going clockwise from a to b.
+func isBetween(angle float64, a float64, b float64) bool {
+	if a < b {
+		return a <= angle && angle <= b
+	}
+	return a <= angle || angle <= b
+}
+
+// fixIfDisliked lightens the dark yellow-greens universally disliked by users.
+func fixIfDisliked(h hct) hct {
+	huePasses := math.Round(h.hue) >= 90.0 && math.Round(h.hue) <= 111.0
+	chromaPasses := math.Round(h.chroma) > 16.0
+	tonePasses := math.Round(h.tone) < 65.0
+	if huePasses && chromaPasses && tonePasses {
+		return hctFrom(h.hue, h.chroma, 70.0)
+	}
+	return h
+}
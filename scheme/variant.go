@@ -0,0 +1,194 @@
+// Copyright (c) 2023 https://github.com/gio-eui
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR a PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+//
+// SPDX-License-Identifier: MIT
+
+package scheme
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/gio-eui/md3-colors/palettes"
+)
+
+// Variant is a style of dynamic scheme, describing how the key palettes are derived from a seed color.
+type Variant int
+
+const (
+	// VariantTonalSpot is the default Material You style: a calm theme with a low chroma primary.
+	VariantTonalSpot Variant = iota
+	// VariantVibrant maximizes the colorfulness of the primary palette.
+	VariantVibrant
+	// VariantExpressive rotates the primary hue away from the seed for a playful theme.
+	VariantExpressive
+	// VariantFidelity keeps the seed color unchanged in the primary palette.
+	VariantFidelity
+	// VariantContent keeps the seed color like VariantFidelity, with an analogous tertiary.
+	VariantContent
+	// VariantMonochrome is a grayscale theme.
+	VariantMonochrome
+	// VariantNeutral is a nearly grayscale theme with a hint of the seed hue.
+	VariantNeutral
+	// VariantRainbow is a playful theme with grayscale surfaces.
+	VariantRainbow
+	// VariantFruitSalad is a playful theme where the seed hue is only used in the tertiary palette.
+	VariantFruitSalad
+)
+
+// String returns the name of the variant.
+func (v Variant) String() string {
+	switch v {
+	case VariantTonalSpot:
+		return "TonalSpot"
+	case VariantVibrant:
+		return "Vibrant"
+	case VariantExpressive:
+		return "Expressive"
+	case VariantFidelity:
+		return "Fidelity"
+	case VariantContent:
+		return "Content"
+	case VariantMonochrome:
+		return "Monochrome"
+	case VariantNeutral:
+		return "Neutral"
+	case VariantRainbow:
+		return "Rainbow"
+	case VariantFruitSalad:
+		return "FruitSalad"
+	default:
+		return "Unknown"
+	}
+}
+
+// ParseVariant returns the variant with the given name, ignoring case,
+// such as "TonalSpot" or "tonalspot".
+func ParseVariant(name string) (Variant, error) {
+	for v := VariantTonalSpot; v <= VariantFruitSalad; v++ {
+		if strings.EqualFold(v.String(), name) {
+			return v, nil
+		}
+	}
+	return VariantTonalSpot, fmt.Errorf("scheme: unknown variant %q", name)
+}
+
+// FromSeed creates a scheme whose key palettes are all derived from a single seed color,
+// following the rules of the given variant.
+// The seed is formatted as an int representing an argb color.
+func FromSeed(seed int, variant Variant, isDark bool) *Scheme {
+	source := hctFromInt(seed)
+	var primary, secondary, tertiary, neutral, neutralVariant *palettes.TonalPalette
+	switch variant {
+	case VariantVibrant:
+		hues := []float64{0, 41, 61, 101, 131, 181, 251, 301, 360}
+		primary = tonalPaletteFromHueAndChroma(source.hue, 200)
+		secondary = tonalPaletteFromHueAndChroma(rotatedHue(source.hue, hues, []float64{18, 15, 10, 12, 15, 18, 15, 12, 12}), 24)
+		tertiary = tonalPaletteFromHueAndChroma(rotatedHue(source.hue, hues, []float64{35, 30, 20, 25, 30, 35, 30, 25, 25}), 32)
+		neutral = tonalPaletteFromHueAndChroma(source.hue, 10)
+		neutralVariant = tonalPaletteFromHueAndChroma(source.hue, 12)
+	case VariantExpressive:
+		hues := []float64{0, 21, 51, 121, 151, 191, 271, 321, 360}
+		primary = tonalPaletteFromHueAndChroma(sanitizeDegrees(source.hue+240), 40)
+		secondary = tonalPaletteFromHueAndChroma(rotatedHue(source.hue, hues, []float64{45, 95, 45, 20, 45, 90, 45, 45, 45}), 24)
+		tertiary = tonalPaletteFromHueAndChroma(rotatedHue(source.hue, hues, []float64{120, 120, 20, 45, 20, 15, 20, 120, 120}), 32)
+		neutral = tonalPaletteFromHueAndChroma(sanitizeDegrees(source.hue+15), 8)
+		neutralVariant = tonalPaletteFromHueAndChroma(sanitizeDegrees(source.hue+15), 12)
+	case VariantFidelity, VariantContent:
+		var tertiaryHct hct
+		if variant == VariantFidelity {
+			tertiaryHct = fixIfDisliked(newTemperatureCache(source).complement())
+		} else {
+			tertiaryHct = fixIfDisliked(newTemperatureCache(source).analogous(3, 6)[2])
+		}
+		primary = palettes.NewTonalPaletteFromInt(seed)
+		secondary = tonalPaletteFromHueAndChroma(source.hue, math.Max(source.chroma-32, source.chroma*0.5))
+		tertiary = palettes.NewTonalPaletteFromInt(tertiaryHct.toInt())
+		neutral = tonalPaletteFromHueAndChroma(source.hue, source.chroma/8)
+		neutralVariant = tonalPaletteFromHueAndChroma(source.hue, source.chroma/8+4)
+	case VariantMonochrome:
+		primary = tonalPaletteFromHueAndChroma(source.hue, 0)
+		secondary = tonalPaletteFromHueAndChroma(source.hue, 0)
+		tertiary = tonalPaletteFromHueAndChroma(source.hue, 0)
+		neutral = tonalPaletteFromHueAndChroma(source.hue, 0)
+		neutralVariant = tonalPaletteFromHueAndChroma(source.hue, 0)
+	case VariantNeutral:
+		primary = tonalPaletteFromHueAndChroma(source.hue, 12)
+		secondary = tonalPaletteFromHueAndChroma(source.hue, 8)
+		tertiary = tonalPaletteFromHueAndChroma(sanitizeDegrees(source.hue+60), 16)
+		neutral = tonalPaletteFromHueAndChroma(source.hue, 2)
+		neutralVariant = tonalPaletteFromHueAndChroma(source.hue, 2)
+	case VariantRainbow:
+		primary = tonalPaletteFromHueAndChroma(source.hue, 48)
+		secondary = tonalPaletteFromHueAndChroma(source.hue, 16)
+		tertiary = tonalPaletteFromHueAndChroma(sanitizeDegrees(source.hue+60), 24)
+		neutral = tonalPaletteFromHueAndChroma(source.hue, 0)
+		neutralVariant = tonalPaletteFromHueAndChroma(source.hue, 0)
+	case VariantFruitSalad:
+		primary = tonalPaletteFromHueAndChroma(sanitizeDegrees(source.hue-50), 48)
+		secondary = tonalPaletteFromHueAndChroma(sanitizeDegrees(source.hue-50), 36)
+		tertiary = tonalPaletteFromHueAndChroma(source.hue, 36)
+		neutral = tonalPaletteFromHueAndChroma(source.hue, 10)
+		neutralVariant = tonalPaletteFromHueAndChroma(source.hue, 16)
+	default:
+		primary = tonalPaletteFromHueAndChroma(source.hue, 36)
+		secondary = tonalPaletteFromHueAndChroma(source.hue, 16)
+		tertiary = tonalPaletteFromHueAndChroma(sanitizeDegrees(source.hue+60), 24)
+		neutral = tonalPaletteFromHueAndChroma(source.hue, 6)
+		neutralVariant = tonalPaletteFromHueAndChroma(source.hue, 8)
+	}
+
+	s := &Scheme{variant: variant, sourceColor: seed}
+	s.setPrimaryTonalPalette(primary, isDark)
+	s.setSecondaryTonalPalette(secondary, isDark)
+	s.setTertiaryTonalPalette(tertiary, isDark)
+	s.setNeutralTonalPalette(neutral, isDark)
+	s.setNeutralVariantTonalPalette(neutralVariant, isDark)
+	s.setErrorTonalPalette(ErrorTonalPalette, isDark)
+	return s
+}
+
+// tonalPaletteFromHueAndChroma creates a tonal palette with the given hue and chroma.
+// The palette is keyed on the in-gamut color of that hue whose chroma is the closest
+// to the requested one, so its tones are the same as if the chroma was kept.
+func tonalPaletteFromHueAndChroma(hue float64, chroma float64) *palettes.TonalPalette {
+	key := hctFrom(hue, chroma, 50)
+	for tone := 1.0; tone < 100 && key.chroma < chroma-1; tone++ {
+		candidate := hctFrom(hue, chroma, tone)
+		if candidate.chroma > key.chroma {
+			key = candidate
+		}
+	}
+	return palettes.NewTonalPaletteFromInt(key.toInt())
+}
+
+// rotatedHue rotates the source hue by the rotation of the hue range it falls in.
+// hues holds the boundaries of the ranges, and rotations the rotation of each range.
+func rotatedHue(sourceHue float64, hues []float64, rotations []float64) float64 {
+	if len(rotations) == 1 {
+		return sanitizeDegrees(sourceHue + rotations[0])
+	}
+	for i := 0; i < len(hues)-1; i++ {
+		if hues[i] < sourceHue && sourceHue < hues[i+1] {
+			return sanitizeDegrees(sourceHue + rotations[i])
+		}
+	}
+	return sourceHue
+}
@@ -0,0 +1,132 @@
+// Copyright (c) 2023 https://github.com/gio-eui
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR a PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+//
+// SPDX-License-Identifier: MIT
+
+// Package theme binds a palette to a Gio material theme.
+package theme
+
+import (
+	"image/color"
+
+	"gioui.org/widget/material"
+	"github.com/gio-eui/md3-palettes/palette"
+	"github.com/gio-eui/md3-palettes/scheme"
+)
+
+// Theme is a Gio material theme whose colors follow the active scheme of a palette.
+//
+// The palette can be changed from any goroutine, such as by SwitchMode or WatchMode, while the
+// material theme is only changed by Update, which must be called on the goroutine drawing
+// the window, typically at the start of each frame.
+type Theme struct {
+	*material.Theme
+
+	palette     *palette.Palette
+	scheme      *scheme.Scheme
+	unsubscribe func()
+}
+
+// New creates a theme bound to a palette. When th is nil, a new material theme is created.
+// invalidate is called after every change of the active scheme, such as with the Invalidate
+// method of the window, so that the next frame calls Update; it may be nil.
+func New(th *material.Theme, p *palette.Palette, invalidate func()) *Theme {
+	if th == nil {
+		th = material.NewTheme()
+	}
+	t := &Theme{
+		Theme:   th,
+		palette: p,
+	}
+	if invalidate != nil {
+		t.unsubscribe = p.Subscribe(func(palette.Change) { invalidate() })
+	}
+	t.Update()
+	return t
+}
+
+// Update applies the active scheme of the palette to the material theme if it has changed,
+// and reports whether it did.
+func (t *Theme) Update() bool {
+	s := t.palette.ActiveScheme()
+	if s == t.scheme {
+		return false
+	}
+	t.scheme = s
+	Apply(t.Theme, s)
+	return true
+}
+
+// Scheme returns the scheme applied by the last Update.
+func (t *Theme) Scheme() *scheme.Scheme {
+	return t.scheme
+}
+
+// Color returns the color of a role in the scheme applied by the last Update,
+// or a zero color if the role is unknown or the palette has no active scheme.
+func (t *Theme) Color(role scheme.Role) color.NRGBA {
+	if t.scheme == nil {
+		return color.NRGBA{}
+	}
+	c, _ := t.scheme.Color(role)
+	return c
+}
+
+// StateLayer returns the colors of a component whose container and content have the given
+// roles in an interaction state, in the scheme applied by the last Update, or zero colors if
+// the palette has no active scheme.
+func (t *Theme) StateLayer(container scheme.Role, content scheme.Role, st scheme.State) scheme.StateColors {
+	if t.scheme == nil {
+		return scheme.StateColors{}
+	}
+	c, _ := t.scheme.StateLayer(container, content, st)
+	return c
+}
+
+// Close stops invalidating on changes of the palette.
+func (t *Theme) Close() {
+	if t.unsubscribe != nil {
+		t.unsubscribe()
+		t.unsubscribe = nil
+	}
+}
+
+// Apply sets the palette of a material theme from a scheme: Bg and Fg are the surface and
+// the content on it, ContrastBg and ContrastFg are the primary color and the content on it.
+// When s is nil, the default colors of material.NewTheme are set.
+func Apply(th *material.Theme, s *scheme.Scheme) {
+	th.Palette = Palette(s)
+}
+
+// defaultPalette is the palette of material.NewTheme.
+var defaultPalette = material.NewTheme().Palette
+
+// Palette returns the material palette of a scheme, or the palette of material.NewTheme
+// when s is nil.
+func Palette(s *scheme.Scheme) material.Palette {
+	if s == nil {
+		return defaultPalette
+	}
+	return material.Palette{
+		Bg:         s.Surface,
+		Fg:         s.OnSurface,
+		ContrastBg: s.Primary,
+		ContrastFg: s.OnPrimary,
+	}
+}
@@ -0,0 +1,79 @@
+// Copyright (c) 2023 https://github.com/gio-eui
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR a PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+//
+// SPDX-License-Identifier: MIT
+
+package theme
+
+import (
+	"image/color"
+	"testing"
+
+	"gioui.org/widget/material"
+	"github.com/gio-eui/md3-palettes/palette"
+	"github.com/gio-eui/md3-palettes/scheme"
+)
+
+func TestThemeSwitchMode(t *testing.T) {
+	p := palette.NewPaletteFromSeed(0xff6750a4, scheme.VariantTonalSpot, 0)
+	var invalidated int
+	th := New(nil, p, func() { invalidated++ })
+	defer th.Close()
+
+	for _, isDark := range []bool{true, false, true} {
+		p.SwitchMode(isDark)
+		if !th.Update() {
+			t.Fatalf("isDark %v: Update did not apply the new scheme", isDark)
+		}
+		if th.Update() {
+			t.Errorf("isDark %v: Update applied an unchanged scheme", isDark)
+		}
+
+		s := p.ActiveScheme()
+		for name, got := range map[string][2]any{
+			"Bg":         {th.Palette.Bg, s.Surface},
+			"Fg":         {th.Palette.Fg, s.OnSurface},
+			"ContrastBg": {th.Palette.ContrastBg, s.Primary},
+			"ContrastFg": {th.Palette.ContrastFg, s.OnPrimary},
+		} {
+			if got[0] != got[1] {
+				t.Errorf("isDark %v: %s = %v, want %v", isDark, name, got[0], got[1])
+			}
+		}
+		if th.Scheme() != s {
+			t.Errorf("isDark %v: Scheme is not the active scheme", isDark)
+		}
+	}
+	if invalidated != 3 {
+		t.Errorf("invalidated %d times, want 3", invalidated)
+	}
+}
+
+func TestThemeWithoutActiveScheme(t *testing.T) {
+	th := New(nil, &palette.Palette{}, nil)
+	if want := material.NewTheme().Palette; th.Palette != want {
+		t.Errorf("Palette = %v, want the default %v", th.Palette, want)
+	}
+	if c := th.Color(scheme.RolePrimary); c != (color.NRGBA{}) {
+		t.Errorf("Color = %v without a scheme", c)
+	}
+	if c := th.StateLayer(scheme.RolePrimary, scheme.RoleOnPrimary, scheme.StateHover); c != (scheme.StateColors{}) {
+		t.Errorf("StateLayer = %v without a scheme", c)
+	}
+}